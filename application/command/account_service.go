@@ -3,183 +3,182 @@ package command
 
 import (
 	"context"
-	"encoding/json"
-	"github.com/google/uuid"
+	"errors"
 	"go-eventsourcing-patterns/domain"
 	"go.opentelemetry.io/otel"
-	"time"
 )
 
+// maxConcurrencyRetries 동시성 충돌 시 커맨드를 다시 시도하는 최대 횟수
+const maxConcurrencyRetries = 3
+
 type AccountCommandService struct {
 	accountStore   domain.AccountStore
 	eventStore     domain.EventStore
-	eventPublisher domain.EventPublisher
+	snapshotStore  domain.SnapshotStore
+	snapshotPolicy domain.SnapshotPolicy
+	outbox         domain.Outbox
 	txManager      domain.TransactionManager
 }
 
+// NewAccountCommandService snapshotStore 가 nil 이면 항상 전체 이벤트를 재생해서 복원
 func NewAccountCommandService(accountStore domain.AccountStore, eventStore domain.EventStore,
-	eventPublisher domain.EventPublisher, txManager domain.TransactionManager) *AccountCommandService {
+	snapshotStore domain.SnapshotStore, snapshotPolicy domain.SnapshotPolicy,
+	outbox domain.Outbox, txManager domain.TransactionManager) *AccountCommandService {
 	return &AccountCommandService{
 		accountStore:   accountStore,
 		eventStore:     eventStore,
-		eventPublisher: eventPublisher,
+		snapshotStore:  snapshotStore,
+		snapshotPolicy: snapshotPolicy,
+		outbox:         outbox,
 		txManager:      txManager,
 	}
 }
 
 // CreateAccount는 Command를 받아서 처리
-func (s *AccountCommandService) CreateAccount(ctx context.Context, cmd domain.CreateAccountCommand) error {
+func (s *AccountCommandService) CreateAccount(ctx context.Context, cmd domain.CreateAccountCommand) (domain.CommandResult, error) {
 	octx, span := otel.Tracer("postgres").Start(ctx, "create-account")
 	defer span.End()
 
-	tctx, err := s.txManager.Begin(octx)
-	if err != nil {
-		return err
-	}
-	defer s.txManager.Rollback(tctx)
-
-	account := &domain.Account{
-		ID:        cmd.AccountId,
-		Balance:   cmd.InitialBalance,
-		UserName:  cmd.UserName,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	if err := s.accountStore.Create(tctx, account); err != nil {
-		return err
-	}
+	return s.execute(octx, cmd.AccountId, cmd)
+}
 
-	eventData := map[string]interface{}{
-		"account_id":      cmd.AccountId,
-		"user_name":       cmd.UserName,
-		"initial_balance": 1000,
-	}
-	byteData, err := json.Marshal(eventData)
-	if err != nil {
-		return err
-	}
+// Deposit은 Command를 받아서 처리
+func (s *AccountCommandService) Deposit(ctx context.Context, cmd domain.DepositCommand) (domain.CommandResult, error) {
+	octx, span := otel.Tracer("postgres").Start(ctx, "deposit-account")
+	defer span.End()
 
-	event := domain.Event{
-		AccountID: account.ID,
-		CreatedAt: time.Now(),
-		EventType: string(domain.AccountCreated),
-		EventData: byteData,
-	}
+	return s.execute(octx, cmd.AccountID, cmd)
+}
 
-	if err := s.eventPublisher.Publish(tctx, event); err != nil {
-		return err
-	}
+// Withdraw은 Command를 받아서 처리
+func (s *AccountCommandService) Withdraw(ctx context.Context, cmd domain.WithdrawCommand) (domain.CommandResult, error) {
+	octx, span := otel.Tracer("postgres").Start(ctx, "withdraw-account")
+	defer span.End()
 
-	return s.txManager.Commit(tctx)
+	return s.execute(octx, cmd.AccountID, cmd)
 }
 
-// Deposit은 Command를 받아서 처리
-func (s *AccountCommandService) Deposit(ctx context.Context, cmd domain.DepositCommand) error {
-	octx, span := otel.Tracer("postgres").Start(ctx, "deposit-account")
-	defer span.End()
+// execute 동시성 충돌(ErrConcurrencyConflict)이 나면 최신 스트림으로 다시 복원해서 재시도
+func (s *AccountCommandService) execute(ctx context.Context, accountID string, cmd interface{}) (domain.CommandResult, error) {
+	var result domain.CommandResult
+	var err error
+	for attempt := 0; attempt <= maxConcurrencyRetries; attempt++ {
+		result, err = s.executeOnce(ctx, accountID, cmd)
+		if !errors.Is(err, domain.ErrConcurrencyConflict) {
+			return result, err
+		}
+	}
+	return result, err
+}
 
-	tctx, err := s.txManager.Begin(octx)
+// executeOnce 이벤트 스트림으로 애그리거트를 복원한 뒤 커맨드를 결정하고,
+// 새 이벤트 저장, accounts 프로젝션 갱신, outbox 기록을 하나의 트랜잭션으로 처리
+// Kafka 발행은 outbox 릴레이가 커밋 이후에 따로 처리
+func (s *AccountCommandService) executeOnce(ctx context.Context, accountID string, cmd interface{}) (domain.CommandResult, error) {
+	tctx, err := s.txManager.Begin(ctx)
 	if err != nil {
-		return err
+		return domain.CommandResult{}, err
 	}
 	defer s.txManager.Rollback(tctx)
 
-	account, err := s.accountStore.FindByID(tctx, cmd.AccountID)
+	aggregate, err := s.loadAggregate(tctx, accountID)
 	if err != nil {
-		return err
+		return domain.CommandResult{}, err
 	}
+	isNew := !aggregate.Exists()
+	expectedVersion := aggregate.Version
 
-	originalBalance := account.Balance
-
-	account.Balance += cmd.Amount
-	account.UpdatedAt = time.Now()
-
-	if err := s.accountStore.Update(tctx, account); err != nil {
-		return err
-	}
-	eventId := uuid.New().String()
-	eventData := map[string]interface{}{
-		"id":               eventId,
-		"account_id":       cmd.AccountID,
-		"amount":           cmd.Amount,
-		"original_balance": originalBalance,
-	}
-	byteData, err := json.Marshal(eventData)
+	events, err := aggregate.Decide(cmd)
 	if err != nil {
-		return err
+		return domain.CommandResult{}, err
 	}
 
-	event := domain.Event{
-		ID:        eventId,
-		AccountID: account.ID,
-		Amount:    cmd.Amount,
-		CreatedAt: time.Now(),
-		EventData: byteData,
-		EventType: string(domain.MoneyDeposited),
+	for _, event := range events {
+		if err := aggregate.Apply(event); err != nil {
+			return domain.CommandResult{}, err
+		}
 	}
 
-	if err := s.eventPublisher.Publish(tctx, event); err != nil {
-		return err
+	if err := s.eventStore.Save(tctx, accountID, expectedVersion, events); err != nil {
+		return domain.CommandResult{}, err
 	}
 
-	return s.txManager.Commit(tctx)
-}
-
-// Withdraw은 Command를 받아서 처리
-func (s *AccountCommandService) Withdraw(ctx context.Context, cmd domain.WithdrawCommand) error {
-	octx, span := otel.Tracer("postgres").Start(ctx, "withdraw-account")
-	defer span.End()
-
-	tctx, err := s.txManager.Begin(octx)
-	if err != nil {
-		return err
+	// accounts 테이블은 이벤트로부터 파생된 상태를 그대로 반영
+	if isNew {
+		err = s.accountStore.Create(tctx, aggregate.ToAccount())
+	} else {
+		err = s.accountStore.Update(tctx, aggregate.ToAccount())
 	}
-	defer s.txManager.Rollback(tctx)
-
-	account, err := s.accountStore.FindByID(tctx, cmd.AccountID)
 	if err != nil {
-		return err
+		return domain.CommandResult{}, err
 	}
 
-	originalBalance := account.Balance
-
-	if account.Balance < cmd.Amount {
-		return domain.ErrInsufficientBalance
+	if s.snapshotStore != nil && s.snapshotPolicy != nil &&
+		s.snapshotPolicy.ShouldSnapshot(aggregate, len(events)) {
+		if err := s.saveSnapshot(tctx, aggregate); err != nil {
+			return domain.CommandResult{}, err
+		}
 	}
 
-	account.Balance -= cmd.Amount
-	account.UpdatedAt = time.Now()
+	if err := s.outbox.Add(tctx, events); err != nil {
+		return domain.CommandResult{}, err
+	}
 
-	if err := s.accountStore.Update(tctx, account); err != nil {
-		return err
+	if err := s.txManager.Commit(tctx); err != nil {
+		return domain.CommandResult{}, err
 	}
 
-	eventId := uuid.New().String()
+	// Save 가 events 에 채운 position 중 마지막 것이 이 커맨드의 결과가 보이기 시작하는 위치
+	result := domain.CommandResult{AccountID: accountID, Version: aggregate.Version}
+	if len(events) > 0 {
+		result.Position = events[len(events)-1].Position
+	}
+	return result, nil
+}
 
-	eventData := map[string]interface{}{
-		"id":               eventId,
-		"account_id":       cmd.AccountID,
-		"amount":           cmd.Amount,
-		"original_balance": originalBalance,
+// TakeSnapshot 정책과 관계없이 계좌의 현재 상태로 스냅샷 생성 (요청 시 스냅샷)
+func (s *AccountCommandService) TakeSnapshot(ctx context.Context, accountID string) error {
+	if s.snapshotStore == nil {
+		return errors.New("snapshot store is not configured")
 	}
-	byteData, err := json.Marshal(eventData)
+
+	aggregate, err := s.loadAggregate(ctx, accountID)
 	if err != nil {
 		return err
 	}
+	if !aggregate.Exists() {
+		return domain.ErrAccountNotFound
+	}
+	return s.saveSnapshot(ctx, aggregate)
+}
 
-	event := domain.Event{
-		ID:        eventId,
-		AccountID: account.ID,
-		Amount:    cmd.Amount,
-		CreatedAt: time.Now(),
-		EventType: string(domain.MoneyWithdrawn),
-		EventData: byteData,
+// loadAggregate 최신 스냅샷이 있으면 그 이후 이벤트만, 없으면 전체 이벤트를 재생해서 복원
+func (s *AccountCommandService) loadAggregate(ctx context.Context, accountID string) (*domain.AccountAggregate, error) {
+	if s.snapshotStore != nil {
+		snapshot, err := s.snapshotStore.Load(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil {
+			events, err := s.eventStore.LoadAfter(ctx, accountID, snapshot.Version)
+			if err != nil {
+				return nil, err
+			}
+			return domain.RestoreAccount(*snapshot, events)
+		}
+	}
+
+	history, err := s.eventStore.Load(ctx, accountID)
+	if err != nil {
+		return nil, err
 	}
+	return domain.RehydrateAccount(accountID, history)
+}
 
-	if err := s.eventPublisher.Publish(tctx, event); err != nil {
+func (s *AccountCommandService) saveSnapshot(ctx context.Context, aggregate *domain.AccountAggregate) error {
+	snapshot, err := aggregate.Snapshot()
+	if err != nil {
 		return err
 	}
-
-	return s.txManager.Commit(tctx)
+	return s.snapshotStore.Save(ctx, snapshot)
 }
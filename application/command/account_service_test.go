@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"go-eventsourcing-patterns/application/projection"
+	"go-eventsourcing-patterns/application/query"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/memory"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountCommandService(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewMemoryDB()
+	accountStore := memory.NewAccountStore(db)
+	eventStore := memory.NewEventStore(db)
+	outbox := memory.NewOutboxStore(db)
+	service := NewAccountCommandService(accountStore, eventStore,
+		memory.NewSnapshotStore(db), domain.SnapshotEvery(2), outbox, db)
+	summaryStore := memory.NewAccountSummaryStore(db)
+	checkpoints := memory.NewCheckpointStore(db)
+	queryService := query.NewAccountQueryService(accountStore, summaryStore, checkpoints, eventStore, time.Second)
+	runner := projection.NewRunner(eventStore, checkpoints, db, 100, time.Millisecond)
+	assert.NoError(t, runner.Register(projection.NewAccountSummaryProjection(summaryStore)))
+
+	created, err := service.CreateAccount(ctx, domain.CreateAccountCommand{
+		AccountId: "account-1", UserName: "kim", InitialBalance: 100,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CommandResult{AccountID: "account-1", Version: 1, Position: 1}, created)
+
+	t.Run("조회 모델이 따라잡지 못하면 ErrReadModelBehind", func(t *testing.T) {
+		short := query.NewAccountQueryService(accountStore, summaryStore, checkpoints, eventStore, 20*time.Millisecond)
+		_, err := short.GetAccountByID(ctx, "account-1", created.Position)
+		assert.ErrorIs(t, err, domain.ErrReadModelBehind)
+	})
+
+	assert.NoError(t, runner.Start(ctx))
+	defer runner.Stop()
+
+	t.Run("입출금 후 조회", func(t *testing.T) {
+		_, err := service.Deposit(ctx, domain.DepositCommand{AccountID: "account-1", Amount: 50})
+		assert.NoError(t, err)
+		withdrawn, err := service.Withdraw(ctx, domain.WithdrawCommand{AccountID: "account-1", Amount: 30})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), withdrawn.Version)
+		assert.Equal(t, int64(3), withdrawn.Position)
+
+		// 커맨드 결과의 position 을 넘기면 프로젝션이 반영할 때까지 기다림
+		account, err := queryService.GetAccountByID(ctx, "account-1", withdrawn.Position)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(120), account.Balance)
+		assert.Equal(t, int64(50), account.TotalDeposits)
+		assert.Equal(t, int64(30), account.TotalWithdrawals)
+		assert.Equal(t, 2, account.TransactionCount)
+	})
+
+	t.Run("실패한 커맨드는 아무것도 남기지 않음", func(t *testing.T) {
+		before, _ := eventStore.Load(ctx, "account-1")
+		pendingBefore, _ := outbox.FetchPending(ctx, 100)
+
+		_, err := service.Withdraw(ctx, domain.WithdrawCommand{AccountID: "account-1", Amount: 1000})
+		assert.ErrorIs(t, err, domain.ErrInsufficientBalance)
+		_, err = service.Deposit(ctx, domain.DepositCommand{AccountID: "account-2", Amount: 10})
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+
+		after, _ := eventStore.Load(ctx, "account-1")
+		pendingAfter, _ := outbox.FetchPending(ctx, 100)
+		assert.Len(t, after, len(before))
+		assert.Len(t, pendingAfter, len(pendingBefore))
+	})
+
+	t.Run("동시에 입금해도 모두 반영", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.Deposit(ctx, domain.DepositCommand{AccountID: "account-1", Amount: 10})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		account, err := accountStore.FindByID(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(220), account.Balance)
+
+		events, _ := eventStore.Load(ctx, "account-1")
+		aggregate, err := domain.RehydrateAccount("account-1", events)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(220), aggregate.Balance)
+		assert.Equal(t, int64(13), aggregate.Version)
+
+		accounts, err := queryService.ListAccounts(ctx, events[len(events)-1].Position)
+		assert.NoError(t, err)
+		assert.Len(t, accounts, 1)
+		assert.Equal(t, int64(220), accounts[0].Balance)
+		assert.Equal(t, int64(150), accounts[0].TotalDeposits)
+		assert.Equal(t, 12, accounts[0].TransactionCount)
+	})
+}
@@ -0,0 +1,75 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+)
+
+// AccountCreatedHandler 계좌 개설을 계좌 주인에게 알림
+type AccountCreatedHandler struct {
+	notifier domain.Notifier
+}
+
+func NewAccountCreatedHandler(notifier domain.Notifier) *AccountCreatedHandler {
+	return &AccountCreatedHandler{notifier: notifier}
+}
+
+func (h *AccountCreatedHandler) Handle(ctx context.Context, event domain.Event) error {
+	data, err := decode[domain.AccountCreatedData](event)
+	if err != nil {
+		return err
+	}
+	return h.notifier.Notify(ctx, event.AccountID,
+		fmt.Sprintf("Account opened for %s with balance %d", data.UserName, data.InitialBalance))
+}
+
+// MoneyDepositHandler 입금과 입금 후 잔액을 알림
+type MoneyDepositHandler struct {
+	notifier domain.Notifier
+}
+
+func NewMoneyDepositHandler(notifier domain.Notifier) *MoneyDepositHandler {
+	return &MoneyDepositHandler{notifier: notifier}
+}
+
+func (h *MoneyDepositHandler) Handle(ctx context.Context, event domain.Event) error {
+	data, err := decode[domain.MoneyDepositedData](event)
+	if err != nil {
+		return err
+	}
+	return h.notifier.Notify(ctx, event.AccountID,
+		fmt.Sprintf("Deposited %d, balance %d", data.Amount, data.OriginalBalance+data.Amount))
+}
+
+// MoneyWithdrawHandler 출금과 출금 후 잔액을 알림
+type MoneyWithdrawHandler struct {
+	notifier domain.Notifier
+}
+
+func NewMoneyWithdrawHandler(notifier domain.Notifier) *MoneyWithdrawHandler {
+	return &MoneyWithdrawHandler{notifier: notifier}
+}
+
+func (h *MoneyWithdrawHandler) Handle(ctx context.Context, event domain.Event) error {
+	data, err := decode[domain.MoneyWithdrawnData](event)
+	if err != nil {
+		return err
+	}
+	return h.notifier.Notify(ctx, event.AccountID,
+		fmt.Sprintf("Withdrew %d, balance %d", data.Amount, data.OriginalBalance-data.Amount))
+}
+
+// decode 이벤트 페이로드를 핸들러가 기대하는 타입으로 디코딩
+func decode[T domain.EventData](event domain.Event) (T, error) {
+	var zero T
+	data, err := event.DecodeData()
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := data.(T)
+	if !ok {
+		return zero, fmt.Errorf("unexpected payload %T for %s event %s", data, event.EventType, event.ID)
+	}
+	return typed, nil
+}
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNotifier struct {
+	messages []string
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, accountID string, message string) error {
+	f.messages = append(f.messages, accountID+": "+message)
+	return nil
+}
+
+func TestNotificationHandlers(t *testing.T) {
+	ctx := context.Background()
+	event := func(data domain.EventData) domain.Event {
+		e, err := domain.NewEvent("account-1", data)
+		assert.NoError(t, err)
+		return e
+	}
+
+	t.Run("이벤트마다 계좌 주인에게 알림", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		assert.NoError(t, NewAccountCreatedHandler(notifier).Handle(ctx,
+			event(domain.AccountCreatedData{AccountID: "account-1", UserName: "kim", InitialBalance: 100})))
+		assert.NoError(t, NewMoneyDepositHandler(notifier).Handle(ctx,
+			event(domain.MoneyDepositedData{AccountID: "account-1", Amount: 50, OriginalBalance: 100})))
+		assert.NoError(t, NewMoneyWithdrawHandler(notifier).Handle(ctx,
+			event(domain.MoneyWithdrawnData{AccountID: "account-1", Amount: 30, OriginalBalance: 150})))
+
+		assert.Equal(t, []string{
+			"account-1: Account opened for kim with balance 100",
+			"account-1: Deposited 50, balance 150",
+			"account-1: Withdrew 30, balance 120",
+		}, notifier.messages)
+	})
+
+	t.Run("다른 타입의 이벤트는 에러", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		err := NewMoneyDepositHandler(notifier).Handle(ctx,
+			event(domain.MoneyWithdrawnData{AccountID: "account-1", Amount: 30}))
+		assert.Error(t, err)
+		assert.Empty(t, notifier.messages)
+	})
+}
@@ -0,0 +1,90 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"log"
+	"time"
+)
+
+// Relay outbox 에 쌓인 메시지를 기록된 순서대로 발행하고 발행 완료로 표시
+type Relay struct {
+	outbox    domain.Outbox
+	publisher domain.EventPublisher
+	txManager domain.TransactionManager
+	batchSize int
+	interval  time.Duration
+}
+
+func NewRelay(outbox domain.Outbox, publisher domain.EventPublisher, txManager domain.TransactionManager,
+	batchSize int, interval time.Duration) *Relay {
+	return &Relay{
+		outbox:    outbox,
+		publisher: publisher,
+		txManager: txManager,
+		batchSize: batchSize,
+		interval:  interval,
+	}
+}
+
+// Run 컨텍스트가 취소될 때까지 interval 마다 미발행 메시지를 발행
+// 한 배치를 꽉 채워 발행했으면 쉬지 않고 바로 다음 배치를 처리
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		sent, err := r.RelayOnce(ctx)
+		if err != nil {
+			log.Printf("Outbox relay failed: %v", err)
+		}
+		if err == nil && sent == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// RelayOnce 미발행 메시지 한 배치를 발행하고 발행된 개수를 반환
+// 발행이 실패하면 그 앞까지만 발행 완료로 표시하고 나머지는 다음 시도에서 순서대로 다시 발행
+// 발행은 메시지를 잠근 트랜잭션의 컨텍스트로 해서, DB 를 쓰는 동기 구독자도 같은 트랜잭션 안에서 처리됨
+// (커넥션이 하나뿐인 SQLite 에서 바깥 컨텍스트로 DB 에 접근하면 릴레이 트랜잭션을 기다리며 멈춤)
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	tctx, err := r.txManager.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.txManager.Rollback(tctx)
+
+	messages, err := r.outbox.FetchPending(tctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var sent []int64
+	var publishErr error
+	for _, msg := range messages {
+		var event domain.Event
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			publishErr = fmt.Errorf("failed to unmarshal outbox message %d: %v", msg.ID, err)
+			break
+		}
+		if err := r.publisher.Publish(tctx, event); err != nil {
+			publishErr = fmt.Errorf("failed to publish outbox message %d: %v", msg.ID, err)
+			break
+		}
+		sent = append(sent, msg.ID)
+	}
+
+	if err := r.outbox.MarkSent(tctx, sent); err != nil {
+		return 0, err
+	}
+	if err := r.txManager.Commit(tctx); err != nil {
+		return 0, err
+	}
+	return len(sent), publishErr
+}
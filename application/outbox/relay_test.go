@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOutbox struct {
+	messages []domain.OutboxMessage
+	sent     map[int64]bool
+}
+
+func (f *fakeOutbox) Add(ctx context.Context, events []domain.Event) error {
+	for _, e := range events {
+		payload, _ := json.Marshal(e)
+		f.messages = append(f.messages, domain.OutboxMessage{ID: int64(len(f.messages) + 1), EventID: e.ID, Payload: payload})
+	}
+	return nil
+}
+
+func (f *fakeOutbox) FetchPending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	var pending []domain.OutboxMessage
+	for _, m := range f.messages {
+		if !f.sent[m.ID] && len(pending) < limit {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+func (f *fakeOutbox) MarkSent(ctx context.Context, ids []int64) error {
+	for _, id := range ids {
+		f.sent[id] = true
+	}
+	return nil
+}
+
+type fakePublisher struct {
+	published []string
+	failOn    string
+	txs       []any
+}
+
+func (f *fakePublisher) Publish(ctx context.Context, event domain.Event) error {
+	if event.ID == f.failOn {
+		return errors.New("broker unavailable")
+	}
+	f.txs = append(f.txs, ctx.Value(domain.TxKey))
+	f.published = append(f.published, event.ID)
+	return nil
+}
+
+func (f *fakePublisher) PublishAll(ctx context.Context, events []domain.Event) error {
+	for _, e := range events {
+		if err := f.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) Begin(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, domain.TxKey, "relay-tx"), nil
+}
+func (fakeTxManager) Commit(ctx context.Context) error   { return nil }
+func (fakeTxManager) Rollback(ctx context.Context) error { return nil }
+
+func TestRelay(t *testing.T) {
+	ctx := context.Background()
+	box := &fakeOutbox{sent: map[int64]bool{}}
+	box.Add(ctx, []domain.Event{{ID: "e1"}, {ID: "e2"}, {ID: "e3"}})
+
+	publisher := &fakePublisher{failOn: "e2"}
+	relay := NewRelay(box, publisher, fakeTxManager{}, 10, 0)
+
+	// e2 발행 실패 시 e1 만 발행 완료, e3 는 순서를 지키기 위해 보류
+	sent, err := relay.RelayOnce(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"e1"}, publisher.published)
+
+	publisher.failOn = ""
+	sent, err = relay.RelayOnce(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"e1", "e2", "e3"}, publisher.published)
+	// 동기 구독자가 릴레이 트랜잭션 안에서 DB 를 쓸 수 있도록 트랜잭션 컨텍스트로 발행
+	assert.Equal(t, []any{"relay-tx", "relay-tx", "relay-tx"}, publisher.txs)
+
+	sent, err = relay.RelayOnce(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
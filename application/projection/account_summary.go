@@ -0,0 +1,85 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+)
+
+// AccountSummaryProjectionName account_summaries 프로젝션 이름 (체크포인트 키)
+const AccountSummaryProjectionName = "account-summaries"
+
+// AccountSummaryProjection 계좌 이벤트로 잔액, 입출금 합계, 거래 횟수, 마지막 거래 시각을 account_summaries 에 유지
+type AccountSummaryProjection struct {
+	name      string
+	summaries domain.AccountSummaryStore
+}
+
+func NewAccountSummaryProjection(summaries domain.AccountSummaryStore) *AccountSummaryProjection {
+	return &AccountSummaryProjection{
+		name:      AccountSummaryProjectionName,
+		summaries: summaries,
+	}
+}
+
+func (p *AccountSummaryProjection) Name() string {
+	return p.name
+}
+
+func (p *AccountSummaryProjection) EventTypes() []domain.EventType {
+	return []domain.EventType{domain.AccountCreated, domain.MoneyDeposited, domain.MoneyWithdrawn}
+}
+
+// Apply 이미 반영한 버전의 이벤트는 건너뛰므로 같은 이벤트를 다시 받아도 결과가 같음
+func (p *AccountSummaryProjection) Apply(ctx context.Context, event domain.Event) error {
+	summary, err := p.summaries.FindByID(ctx, event.AccountID)
+	if errors.Is(err, domain.ErrAccountNotFound) {
+		if event.EventType != string(domain.AccountCreated) {
+			return fmt.Errorf("%s event before %s: %w", event.EventType, domain.AccountCreated, err)
+		}
+		summary = &domain.AccountSummary{AccountID: event.AccountID}
+	} else if err != nil {
+		return err
+	}
+	if event.Version <= summary.Version {
+		return nil
+	}
+
+	if err := summary.Apply(event); err != nil {
+		return err
+	}
+	return p.summaries.Save(ctx, summary)
+}
+
+// Reset 모든 계좌 요약 삭제
+func (p *AccountSummaryProjection) Reset(ctx context.Context) error {
+	return p.summaries.Reset(ctx)
+}
+
+// Forget 계좌 요약 삭제
+func (p *AccountSummaryProjection) Forget(ctx context.Context, accountIDs []string) error {
+	return p.summaries.Delete(ctx, accountIDs)
+}
+
+// Shadow 저장소가 임시 테이블을 지원하면 그 테이블에 쓰는 프로젝션을 반환
+func (p *AccountSummaryProjection) Shadow(ctx context.Context) (Projection, error) {
+	shadower, ok := p.summaries.(domain.AccountSummaryShadower)
+	if !ok {
+		return nil, fmt.Errorf("%s: store does not support shadow tables", p.name)
+	}
+	shadow, err := shadower.CreateShadow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountSummaryProjection{name: p.name + "-shadow", summaries: shadow}, nil
+}
+
+// Promote 임시 테이블을 account_summaries 로 교체
+func (p *AccountSummaryProjection) Promote(ctx context.Context) error {
+	shadower, ok := p.summaries.(domain.AccountSummaryShadower)
+	if !ok {
+		return fmt.Errorf("%s: store does not support shadow tables", p.name)
+	}
+	return shadower.PromoteShadow(ctx)
+}
@@ -0,0 +1,46 @@
+package projection
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/memory"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountSummaryProjection(t *testing.T) {
+	ctx := context.Background()
+	summaries := memory.NewAccountSummaryStore(memory.NewMemoryDB())
+	p := NewAccountSummaryProjection(summaries)
+
+	var events []domain.Event
+	for _, data := range []domain.EventData{
+		domain.AccountCreatedData{AccountID: "account-1", UserName: "kim", InitialBalance: 100},
+		domain.MoneyDepositedData{AccountID: "account-1", Amount: 50},
+		domain.MoneyWithdrawnData{AccountID: "account-1", Amount: 30},
+	} {
+		event, err := domain.NewEvent("account-1", data)
+		assert.NoError(t, err)
+		event.Version = int64(len(events)) + 1
+		events = append(events, event)
+	}
+
+	// 같은 이벤트를 다시 받아도 한 번만 반영
+	for _, event := range append(events, events...) {
+		assert.NoError(t, p.Apply(ctx, event))
+	}
+
+	summary, err := summaries.FindByID(ctx, "account-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "kim", summary.UserName)
+	assert.Equal(t, int64(120), summary.Balance)
+	assert.Equal(t, int64(50), summary.TotalDeposits)
+	assert.Equal(t, int64(30), summary.TotalWithdrawals)
+	assert.Equal(t, 2, summary.TransactionCount)
+	assert.Equal(t, events[2].CreatedAt, summary.LastActivityAt)
+
+	orphan, _ := domain.NewEvent("account-2", domain.MoneyDepositedData{AccountID: "account-2", Amount: 10})
+	orphan.Version = 2
+	assert.ErrorIs(t, p.Apply(ctx, orphan), domain.ErrAccountNotFound)
+}
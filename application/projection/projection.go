@@ -0,0 +1,37 @@
+package projection
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+)
+
+// Projection 이벤트 로그를 읽어서 조회용 모델을 만드는 구독자
+type Projection interface {
+	// Name 체크포인트를 구분하는 이름, 바꾸면 처음부터 다시 만들어짐
+	Name() string
+	// EventTypes 처리할 이벤트 타입, 비어 있으면 모든 이벤트
+	EventTypes() []domain.EventType
+	// Apply 이벤트 하나를 반영
+	// ctx 에는 체크포인트와 같은 트랜잭션이 담겨 있으므로 쓰기는 모두 ctx 로 해야 함
+	Apply(ctx context.Context, event domain.Event) error
+}
+
+// Resettable 조회 모델을 비워서 처음부터 다시 만들 수 있는 프로젝션
+type Resettable interface {
+	// Reset 프로젝션이 만든 데이터를 모두 삭제, ctx 의 트랜잭션 안에서 실행됨
+	Reset(ctx context.Context) error
+}
+
+// Forgettable 일부 스트림에서 만든 데이터만 지우고 다시 만들 수 있는 프로젝션 (Replay 에 필요)
+type Forgettable interface {
+	// Forget streamIDs 스트림에서 만든 데이터를 삭제, ctx 의 트랜잭션 안에서 실행됨
+	Forget(ctx context.Context, streamIDs []string) error
+}
+
+// Shadowable 기존 조회 모델을 그대로 둔 채 임시 테이블에 다시 만들고 다 따라잡으면 한 번에 교체할 수 있는 프로젝션
+type Shadowable interface {
+	// Shadow 비어 있는 임시 테이블에 쓰는 프로젝션을 반환, 체크포인트를 따로 쓰도록 이름이 달라야 함
+	Shadow(ctx context.Context) (Projection, error)
+	// Promote 임시 테이블을 실제 조회 모델로 교체, ctx 의 트랜잭션 안에서 실행됨
+	Promote(ctx context.Context) error
+}
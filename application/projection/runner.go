@@ -0,0 +1,471 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownProjection = errors.New("unknown projection")
+	ErrRunnerStarted     = errors.New("projection runner already started")
+	// ErrInvalidReplayPosition Replay 시작 위치가 0 보다 작거나 프로젝션의 체크포인트보다 뒤임
+	ErrInvalidReplayPosition = errors.New("invalid replay position")
+)
+
+// Status 프로젝션 진행 상태
+type Status struct {
+	Name      string
+	Running   bool
+	Position  int64     // 마지막으로 반영한 이벤트의 position
+	UpdatedAt time.Time // 체크포인트가 마지막으로 기록된 시각
+	LastError error     // 마지막 배치가 실패했으면 그 에러, 성공하면 지워짐
+}
+
+// worker 등록된 프로젝션과 실행 상태
+type worker struct {
+	projection Projection
+	types      map[string]bool
+	running    bool
+	lastErr    error
+}
+
+// handles 프로젝션이 처리하는 이벤트인지 확인
+func (w *worker) handles(event domain.Event) bool {
+	return len(w.types) == 0 || w.types[event.EventType]
+}
+
+// Runner 이벤트 로그를 position 순서대로 읽어서 프로젝션마다 따로 반영
+// 배치마다 프로젝션의 쓰기와 체크포인트를 한 트랜잭션으로 커밋하므로, 중간에 멈춰도 다시 시작하면 이어서 반영됨
+// 같은 프로젝션의 러너는 한 프로세스에서만 실행해야 함
+type Runner struct {
+	eventStore  domain.EventStore
+	checkpoints domain.CheckpointStore
+	txManager   domain.TransactionManager
+	batchSize   int
+	interval    time.Duration
+
+	mu      sync.Mutex
+	workers []*worker
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func NewRunner(eventStore domain.EventStore, checkpoints domain.CheckpointStore, txManager domain.TransactionManager,
+	batchSize int, interval time.Duration) *Runner {
+	return &Runner{
+		eventStore:  eventStore,
+		checkpoints: checkpoints,
+		txManager:   txManager,
+		batchSize:   batchSize,
+		interval:    interval,
+	}
+}
+
+// Register 프로젝션 등록, Start 전에 호출해야 함
+func (r *Runner) Register(p Projection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return ErrRunnerStarted
+	}
+	if p.Name() == "" {
+		return errors.New("projection name is required")
+	}
+	for _, w := range r.workers {
+		if w.projection.Name() == p.Name() {
+			return fmt.Errorf("projection %s is already registered", p.Name())
+		}
+	}
+
+	r.workers = append(r.workers, newWorker(p))
+	return nil
+}
+
+func newWorker(p Projection) *worker {
+	types := make(map[string]bool)
+	for _, t := range p.EventTypes() {
+		types[string(t)] = true
+	}
+	return &worker{projection: p, types: types}
+}
+
+// Start 프로젝션마다 고루틴을 띄워서 체크포인트 이후의 이벤트를 계속 반영
+func (r *Runner) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return ErrRunnerStarted
+	}
+	ctx, r.cancel = context.WithCancel(ctx)
+	for _, w := range r.workers {
+		w.running = true
+		r.wg.Add(1)
+		go r.run(ctx, w)
+	}
+	return nil
+}
+
+// Stop 실행 중인 배치가 끝날 때까지 기다린 뒤 모든 프로젝션을 멈춤
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	r.wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cancel = nil
+	for _, w := range r.workers {
+		w.running = false
+	}
+}
+
+// Status 등록된 프로젝션들의 상태를 등록 순서대로 조회
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	checkpoints, err := r.checkpoints.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]domain.ProjectionCheckpoint, len(checkpoints))
+	for _, c := range checkpoints {
+		byName[c.ProjectionName] = c
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]Status, 0, len(r.workers))
+	for _, w := range r.workers {
+		name := w.projection.Name()
+		statuses = append(statuses, Status{
+			Name:      name,
+			Running:   w.running,
+			Position:  byName[name].Position,
+			UpdatedAt: byName[name].UpdatedAt,
+			LastError: w.lastErr,
+		})
+	}
+	return statuses, nil
+}
+
+// CatchUp 이름이 name 인 프로젝션에 현재까지 저장된 이벤트를 모두 반영하고 반영한 이벤트 수를 반환
+// 실행 중인 러너와 같은 프로젝션에 동시에 호출하면 안 됨
+func (r *Runner) CatchUp(ctx context.Context, name string) (int, error) {
+	w, err := r.worker(name)
+	if err != nil {
+		return 0, err
+	}
+	return r.catchUp(ctx, w)
+}
+
+// Reset 프로젝션의 조회 모델을 비우고 체크포인트를 처음으로 되돌림 (다시 반영하지는 않음)
+// 이 프로젝션을 실행 중인 러너가 없을 때 호출해야 함
+func (r *Runner) Reset(ctx context.Context, name string) error {
+	w, err := r.worker(name)
+	if err != nil {
+		return err
+	}
+	resettable, ok := w.projection.(Resettable)
+	if !ok {
+		return fmt.Errorf("projection %s cannot be reset", name)
+	}
+
+	tctx, err := r.txManager.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.txManager.Rollback(tctx)
+
+	if err := resettable.Reset(tctx); err != nil {
+		return fmt.Errorf("failed to reset projection %s: %w", name, err)
+	}
+	if err := r.checkpoints.Delete(tctx, name); err != nil {
+		return err
+	}
+	return r.txManager.Commit(tctx)
+}
+
+// Rebuild 프로젝션을 비우고 저장된 이벤트 전체를 처음부터 다시 반영
+// 이 프로젝션을 실행 중인 러너가 없을 때 호출해야 함
+func (r *Runner) Rebuild(ctx context.Context, name string) (int, error) {
+	if err := r.Reset(ctx, name); err != nil {
+		return 0, err
+	}
+	return r.CatchUp(ctx, name)
+}
+
+// Replay fromPosition 이후의 이벤트가 속한 스트림의 데이터를 지우고, 그 스트림들을 처음부터 체크포인트까지 다시 반영
+// 지우기와 다시 반영하기는 한 트랜잭션으로 커밋하고, 그 뒤 체크포인트 이후의 이벤트도 이어서 반영
+// 다시 반영한 이벤트 수를 반환하며, 이 프로젝션을 실행 중인 러너가 없을 때 호출해야 함
+func (r *Runner) Replay(ctx context.Context, name string, fromPosition int64) (int, error) {
+	w, err := r.worker(name)
+	if err != nil {
+		return 0, err
+	}
+	forgettable, ok := w.projection.(Forgettable)
+	if !ok {
+		return 0, fmt.Errorf("projection %s cannot be replayed", name)
+	}
+
+	tctx, err := r.txManager.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.txManager.Rollback(tctx)
+
+	position, err := r.checkpoints.Load(tctx, name)
+	if err != nil {
+		return 0, err
+	}
+	if fromPosition < 0 || fromPosition > position {
+		return 0, fmt.Errorf("%w: %d is outside 0-%d of %s", ErrInvalidReplayPosition, fromPosition, position, name)
+	}
+
+	streamIDs, err := r.streamsBetween(tctx, w, fromPosition, position)
+	if err != nil {
+		return 0, err
+	}
+	if err := forgettable.Forget(tctx, streamIDs); err != nil {
+		return 0, fmt.Errorf("failed to forget %d streams of %s: %w", len(streamIDs), name, err)
+	}
+
+	total := 0
+	for _, id := range streamIDs {
+		n, err := r.reapply(tctx, w, id, position)
+		total += n
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := r.txManager.Commit(tctx); err != nil {
+		return 0, err
+	}
+
+	n, err := r.catchUp(ctx, w)
+	return total + n, err
+}
+
+// RebuildShadow 임시 테이블에 프로젝션을 처음부터 다시 만들고, 다 따라잡으면 기존 조회 모델과 한 번에 교체
+// 재구성하는 동안 기존 조회 모델과 이 프로젝션의 러너는 그대로 동작함
+// 마지막으로 남은 이벤트 반영, 테이블 교체, 체크포인트 이동은 한 트랜잭션으로 커밋
+func (r *Runner) RebuildShadow(ctx context.Context, name string) (int, error) {
+	w, err := r.worker(name)
+	if err != nil {
+		return 0, err
+	}
+	shadowable, ok := w.projection.(Shadowable)
+	if !ok {
+		return 0, fmt.Errorf("projection %s does not support shadow rebuilds", name)
+	}
+
+	shadow, err := shadowable.Shadow(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create shadow of %s: %w", name, err)
+	}
+	sw := newWorker(shadow)
+	if sw.projection.Name() == name {
+		return 0, fmt.Errorf("shadow of %s must have its own name", name)
+	}
+	if err := r.checkpoints.Delete(ctx, sw.projection.Name()); err != nil {
+		return 0, err
+	}
+
+	total, err := r.catchUp(ctx, sw)
+	if err != nil {
+		return total, err
+	}
+
+	tctx, err := r.txManager.Begin(ctx)
+	if err != nil {
+		return total, err
+	}
+	defer r.txManager.Rollback(tctx)
+
+	for {
+		events, err := r.next(tctx, sw)
+		if err != nil {
+			return total, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		if err := r.apply(tctx, sw, events); err != nil {
+			return total, err
+		}
+		total += len(events)
+	}
+
+	position, err := r.checkpoints.Load(tctx, sw.projection.Name())
+	if err != nil {
+		return total, err
+	}
+	if err := shadowable.Promote(tctx); err != nil {
+		return total, fmt.Errorf("failed to promote shadow of %s: %w", name, err)
+	}
+	if err := r.checkpoints.Save(tctx, name, position); err != nil {
+		return total, err
+	}
+	if err := r.checkpoints.Delete(tctx, sw.projection.Name()); err != nil {
+		return total, err
+	}
+	return total, r.txManager.Commit(tctx)
+}
+
+// catchUp 현재까지 저장된 이벤트를 배치 단위로 모두 반영
+func (r *Runner) catchUp(ctx context.Context, w *worker) (int, error) {
+	total := 0
+	for {
+		n, err := r.step(ctx, w)
+		total += n
+		if err != nil || n < r.batchSize {
+			return total, err
+		}
+	}
+}
+
+// streamsBetween fromPosition 초과 toPosition 이하에서 프로젝션이 처리하는 이벤트가 있는 스트림 ID 를 처음 나온 순서대로 반환
+func (r *Runner) streamsBetween(ctx context.Context, w *worker, fromPosition, toPosition int64) ([]string, error) {
+	var streamIDs []string
+	seen := make(map[string]bool)
+	for fromPosition < toPosition {
+		events, err := r.eventStore.ReadAll(ctx, fromPosition, r.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		for _, event := range events {
+			if event.Position > toPosition {
+				break
+			}
+			if w.handles(event) && !seen[event.AccountID] {
+				seen[event.AccountID] = true
+				streamIDs = append(streamIDs, event.AccountID)
+			}
+		}
+		fromPosition = events[len(events)-1].Position
+	}
+	return streamIDs, nil
+}
+
+// reapply 스트림의 이벤트 중 toPosition 까지를 처음부터 다시 반영, ctx 의 트랜잭션 안에서 호출
+func (r *Runner) reapply(ctx context.Context, w *worker, streamID string, toPosition int64) (int, error) {
+	it := r.eventStore.Stream(ctx, streamID, domain.EventQuery{EventTypes: w.projection.EventTypes()})
+	defer it.Close()
+
+	n := 0
+	for it.Next() {
+		event := it.Event()
+		if event.Position > toPosition {
+			break
+		}
+		if err := w.projection.Apply(ctx, event); err != nil {
+			return n, fmt.Errorf("projection %s failed to reapply event at position %d (%s %s): %w",
+				w.projection.Name(), event.Position, event.EventType, event.ID, err)
+		}
+		n++
+	}
+	return n, it.Err()
+}
+
+func (r *Runner) worker(name string) (*worker, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, w := range r.workers {
+		if w.projection.Name() == name {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownProjection, name)
+}
+
+// run 컨텍스트가 취소될 때까지 interval 마다 새 이벤트를 반영
+// 한 배치를 꽉 채워 반영했으면 쉬지 않고 바로 다음 배치를 처리
+func (r *Runner) run(ctx context.Context, w *worker) {
+	defer r.wg.Done()
+	for {
+		n, err := r.step(ctx, w)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Projection failed: Name=%s, Error=%v", w.projection.Name(), err)
+		}
+		if err == nil && n == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// step 체크포인트 이후의 이벤트 한 배치를 반영하고 체크포인트를 같은 트랜잭션으로 기록
+// 반영에 실패하면 배치 전체가 롤백되고 다음 시도에서 같은 배치부터 다시 반영
+func (r *Runner) step(ctx context.Context, w *worker) (n int, err error) {
+	defer func() {
+		// 멈추느라 취소된 배치는 실패로 남기지 않음
+		if ctx.Err() == nil {
+			r.mu.Lock()
+			w.lastErr = err
+			r.mu.Unlock()
+		}
+	}()
+
+	events, err := r.next(ctx, w)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	tctx, err := r.txManager.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.txManager.Rollback(tctx)
+
+	if err := r.apply(tctx, w, events); err != nil {
+		return 0, err
+	}
+	if err := r.txManager.Commit(tctx); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+// next 체크포인트 이후의 이벤트 한 배치를 읽음
+func (r *Runner) next(ctx context.Context, w *worker) ([]domain.Event, error) {
+	position, err := r.checkpoints.Load(ctx, w.projection.Name())
+	if err != nil {
+		return nil, err
+	}
+	return r.eventStore.ReadAll(ctx, position, r.batchSize)
+}
+
+// apply 이벤트들을 프로젝션에 반영하고 체크포인트를 마지막 이벤트로 옮김, ctx 의 트랜잭션 안에서 호출
+func (r *Runner) apply(ctx context.Context, w *worker, events []domain.Event) error {
+	name := w.projection.Name()
+	for _, event := range events {
+		if !w.handles(event) {
+			continue
+		}
+		if err := w.projection.Apply(ctx, event); err != nil {
+			return fmt.Errorf("projection %s failed to apply event at position %d (%s %s): %w",
+				name, event.Position, event.EventType, event.ID, err)
+		}
+	}
+	return r.checkpoints.Save(ctx, name, events[len(events)-1].Position)
+}
@@ -0,0 +1,277 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/memory"
+	"go-eventsourcing-patterns/infrastructure/persistence/sqlite"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// depositTotals 계좌별 입금 합계를 메모리 계좌 저장소의 잔액으로 기록하는 테스트용 프로젝션
+type depositTotals struct {
+	accounts *memory.AccountStore
+	failOn   int64 // 이 금액의 입금을 만나면 실패
+}
+
+func (p *depositTotals) Name() string { return "deposit-totals" }
+
+func (p *depositTotals) EventTypes() []domain.EventType {
+	return []domain.EventType{domain.MoneyDeposited}
+}
+
+func (p *depositTotals) Apply(ctx context.Context, event domain.Event) error {
+	data, err := event.DecodeData()
+	if err != nil {
+		return err
+	}
+	deposited := data.(domain.MoneyDepositedData)
+	if deposited.Amount == p.failOn {
+		return errors.New("projection failed")
+	}
+
+	account, err := p.accounts.FindByID(ctx, event.AccountID)
+	if errors.Is(err, domain.ErrAccountNotFound) {
+		return p.accounts.Create(ctx, &domain.Account{ID: event.AccountID, Balance: deposited.Amount})
+	}
+	if err != nil {
+		return err
+	}
+	account.Balance += deposited.Amount
+	return p.accounts.Update(ctx, account)
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, failOn int64) (*Runner, *memory.EventStore, *memory.AccountStore, *memory.CheckpointStore) {
+		db := memory.NewMemoryDB()
+		eventStore := memory.NewEventStore(db)
+		accounts := memory.NewAccountStore(db)
+		checkpoints := memory.NewCheckpointStore(db)
+
+		runner := NewRunner(eventStore, checkpoints, db, 2, time.Millisecond)
+		assert.NoError(t, runner.Register(&depositTotals{accounts: accounts, failOn: failOn}))
+		assert.Error(t, runner.Register(&depositTotals{accounts: accounts}))
+		return runner, eventStore, accounts, checkpoints
+	}
+	save := func(t *testing.T, store domain.EventStore, accountID string, version int64, data ...domain.EventData) {
+		var events []domain.Event
+		for _, d := range data {
+			event, err := domain.NewEvent(accountID, d)
+			assert.NoError(t, err)
+			events = append(events, event)
+		}
+		assert.NoError(t, store.Save(ctx, accountID, version, events))
+	}
+
+	t.Run("체크포인트 이후의 이벤트만 반영", func(t *testing.T) {
+		runner, store, accounts, checkpoints := setup(t, 0)
+		save(t, store, "account-1", 0,
+			domain.AccountCreatedData{AccountID: "account-1"},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 100},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 50})
+
+		n, err := runner.CatchUp(ctx, "deposit-totals")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+
+		save(t, store, "account-1", 3, domain.MoneyWithdrawnData{AccountID: "account-1", Amount: 30})
+		save(t, store, "account-2", 0, domain.MoneyDepositedData{AccountID: "account-2", Amount: 70})
+		n, err = runner.CatchUp(ctx, "deposit-totals")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		account, err := accounts.FindByID(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(150), account.Balance)
+		position, err := checkpoints.Load(ctx, "deposit-totals")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), position)
+
+		_, err = runner.CatchUp(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrUnknownProjection)
+	})
+
+	t.Run("실패한 배치는 프로젝션의 쓰기와 체크포인트가 함께 롤백", func(t *testing.T) {
+		runner, store, accounts, checkpoints := setup(t, 13)
+		save(t, store, "account-1", 0,
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 10},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 20},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 30},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 13})
+
+		n, err := runner.CatchUp(ctx, "deposit-totals")
+		assert.Error(t, err)
+		assert.Equal(t, 2, n)
+
+		// 첫 배치만 커밋되고 실패한 두 번째 배치의 입금 30 은 반영되지 않음
+		account, err := accounts.FindByID(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30), account.Balance)
+		position, err := checkpoints.Load(ctx, "deposit-totals")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), position)
+
+		statuses, err := runner.Status(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, statuses, 1)
+		assert.Equal(t, int64(2), statuses[0].Position)
+		assert.ErrorContains(t, statuses[0].LastError, "position 4")
+	})
+
+	t.Run("Start 후 새 이벤트를 따라 반영하고 Stop 으로 멈춤", func(t *testing.T) {
+		runner, store, accounts, _ := setup(t, 0)
+		assert.NoError(t, runner.Start(ctx))
+		assert.ErrorIs(t, runner.Start(ctx), ErrRunnerStarted)
+
+		for v := int64(0); v < 5; v++ {
+			save(t, store, "account-1", v, domain.MoneyDepositedData{AccountID: "account-1", Amount: 10})
+		}
+		assert.Eventually(t, func() bool {
+			account, err := accounts.FindByID(ctx, "account-1")
+			return err == nil && account.Balance == 50
+		}, time.Second, time.Millisecond)
+
+		statuses, err := runner.Status(ctx)
+		assert.NoError(t, err)
+		assert.True(t, statuses[0].Running)
+
+		runner.Stop()
+		statuses, err = runner.Status(ctx)
+		assert.NoError(t, err)
+		assert.False(t, statuses[0].Running)
+		assert.Equal(t, int64(5), statuses[0].Position)
+		assert.NoError(t, statuses[0].LastError)
+	})
+
+	t.Run("Rebuild 는 조회 모델을 비우고 처음부터 다시 반영", func(t *testing.T) {
+		db := memory.NewMemoryDB()
+		store := memory.NewEventStore(db)
+		summaries := memory.NewAccountSummaryStore(db)
+		checkpoints := memory.NewCheckpointStore(db)
+		runner := NewRunner(store, checkpoints, db, 2, time.Millisecond)
+		assert.NoError(t, runner.Register(NewAccountSummaryProjection(summaries)))
+
+		save(t, store, "account-1", 0,
+			domain.AccountCreatedData{AccountID: "account-1", InitialBalance: 100},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 50},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 20})
+		_, err := runner.CatchUp(ctx, AccountSummaryProjectionName)
+		assert.NoError(t, err)
+
+		// 프로젝션 로직이 바뀌어 조회 모델이 틀어진 상황
+		summary, _ := summaries.FindByID(ctx, "account-1")
+		summary.Balance = 0
+		assert.NoError(t, summaries.Save(ctx, summary))
+
+		n, err := runner.Rebuild(ctx, AccountSummaryProjectionName)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+		summary, _ = summaries.FindByID(ctx, "account-1")
+		assert.Equal(t, int64(170), summary.Balance)
+
+		assert.NoError(t, runner.Reset(ctx, AccountSummaryProjectionName))
+		_, err = summaries.FindByID(ctx, "account-1")
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+		position, _ := checkpoints.Load(ctx, AccountSummaryProjectionName)
+		assert.Equal(t, int64(0), position)
+	})
+
+	t.Run("Replay 는 시작 위치 이후 이벤트가 있는 계좌만 지우고 처음부터 다시 반영", func(t *testing.T) {
+		db := memory.NewMemoryDB()
+		store := memory.NewEventStore(db)
+		summaries := memory.NewAccountSummaryStore(db)
+		checkpoints := memory.NewCheckpointStore(db)
+		runner := NewRunner(store, checkpoints, db, 2, time.Millisecond)
+		assert.NoError(t, runner.Register(NewAccountSummaryProjection(summaries)))
+
+		save(t, store, "account-1", 0,
+			domain.AccountCreatedData{AccountID: "account-1", InitialBalance: 100},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 50})
+		save(t, store, "account-2", 0,
+			domain.AccountCreatedData{AccountID: "account-2", InitialBalance: 10})
+		save(t, store, "account-1", 2,
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 20})
+		_, err := runner.CatchUp(ctx, AccountSummaryProjectionName)
+		assert.NoError(t, err)
+
+		// 프로젝션 로직이 바뀌어 조회 모델이 틀어진 상황
+		for _, id := range []string{"account-1", "account-2"} {
+			summary, _ := summaries.FindByID(ctx, id)
+			summary.Balance = 0
+			assert.NoError(t, summaries.Save(ctx, summary))
+		}
+
+		_, err = runner.Replay(ctx, AccountSummaryProjectionName, 5)
+		assert.ErrorIs(t, err, ErrInvalidReplayPosition)
+		_, err = runner.Replay(ctx, AccountSummaryProjectionName, -1)
+		assert.ErrorIs(t, err, ErrInvalidReplayPosition)
+
+		// position 3 이후에는 account-1 의 이벤트만 있으므로 account-1 만 처음부터 다시 반영
+		save(t, store, "account-2", 1,
+			domain.MoneyDepositedData{AccountID: "account-2", Amount: 5})
+		n, err := runner.Replay(ctx, AccountSummaryProjectionName, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, n)
+
+		summary, _ := summaries.FindByID(ctx, "account-1")
+		assert.Equal(t, int64(170), summary.Balance)
+		assert.Equal(t, int64(3), summary.Version)
+		summary, _ = summaries.FindByID(ctx, "account-2")
+		assert.Equal(t, int64(5), summary.Balance) // 반영되지 않은 입금만 이어서 반영
+		position, _ := checkpoints.Load(ctx, AccountSummaryProjectionName)
+		assert.Equal(t, int64(5), position)
+	})
+
+	t.Run("RebuildShadow 는 임시 테이블에 다시 만든 뒤 교체", func(t *testing.T) {
+		db, err := sqlite.NewSQLiteDB(filepath.Join(t.TempDir(), "eventstore.db"))
+		assert.NoError(t, err)
+		defer db.Close()
+		store := sqlite.NewEventStore(db)
+		summaries := sqlite.NewAccountSummaryStore(db)
+		checkpoints := sqlite.NewCheckpointStore(db)
+		runner := NewRunner(store, checkpoints, db, 2, time.Millisecond)
+		assert.NoError(t, runner.Register(NewAccountSummaryProjection(summaries)))
+
+		save(t, store, "account-1", 0,
+			domain.AccountCreatedData{AccountID: "account-1", InitialBalance: 100},
+			domain.MoneyDepositedData{AccountID: "account-1", Amount: 50},
+			domain.MoneyWithdrawnData{AccountID: "account-1", Amount: 20})
+		save(t, store, "account-2", 0,
+			domain.AccountCreatedData{AccountID: "account-2", InitialBalance: 10})
+		_, err = runner.CatchUp(ctx, AccountSummaryProjectionName)
+		assert.NoError(t, err)
+
+		summary, _ := summaries.FindByID(ctx, "account-1")
+		summary.Balance = 0
+		assert.NoError(t, summaries.Save(ctx, summary))
+
+		n, err := runner.RebuildShadow(ctx, AccountSummaryProjectionName)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, n)
+
+		all, err := summaries.ListAll(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, all, 2)
+		assert.Equal(t, int64(130), all[0].Balance)
+
+		checkpointList, err := checkpoints.List(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, checkpointList, 1)
+		assert.Equal(t, int64(4), checkpointList[0].Position)
+
+		// 교체된 테이블에 계속 반영
+		save(t, store, "account-2", 1, domain.MoneyDepositedData{AccountID: "account-2", Amount: 5})
+		_, err = runner.CatchUp(ctx, AccountSummaryProjectionName)
+		assert.NoError(t, err)
+		summary, err = summaries.FindByID(ctx, "account-2")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(15), summary.Balance)
+	})
+}
@@ -2,130 +2,167 @@ package query
 
 import (
 	"context"
+	"fmt"
+	"go-eventsourcing-patterns/application/projection"
 	"go-eventsourcing-patterns/domain"
+	"time"
 )
 
+// catchUpPollInterval 조회 모델이 따라잡기를 기다리는 동안 체크포인트를 확인하는 간격
+const catchUpPollInterval = 10 * time.Millisecond
+
 type AccountQueryService struct {
-	eventStore   domain.EventStore
-	accountStore domain.AccountStore
+	eventStore     domain.EventStore
+	accountStore   domain.AccountStore
+	summaryStore   domain.AccountSummaryStore
+	checkpoints    domain.CheckpointStore
+	catchUpTimeout time.Duration
 }
 
+// NewAccountQueryService catchUpTimeout 은 최소 position 을 요청받았을 때 조회 모델을 기다리는 최대 시간
 func NewAccountQueryService(
 	accountStore domain.AccountStore,
+	summaryStore domain.AccountSummaryStore,
+	checkpoints domain.CheckpointStore,
 	eventStore domain.EventStore,
+	catchUpTimeout time.Duration,
 ) *AccountQueryService {
 	return &AccountQueryService{
-		eventStore:   eventStore,
-		accountStore: accountStore,
+		eventStore:     eventStore,
+		accountStore:   accountStore,
+		summaryStore:   summaryStore,
+		checkpoints:    checkpoints,
+		catchUpTimeout: catchUpTimeout,
 	}
 }
 
-func (s *AccountQueryService) GetAccountByID(ctx context.Context, accountID string) (*domain.AccountResponse, error) {
-	// 계정 정보 조회
-	account, err := s.accountStore.FindByID(ctx, accountID)
-	if err != nil {
+// GetAccountByID 계좌 요약 조회 모델에서 잔액과 입출금 합계를 함께 조회
+func (s *AccountQueryService) GetAccountByID(ctx context.Context, accountID string, minPosition int64) (*domain.AccountResponse, error) {
+	if err := s.waitFor(ctx, minPosition); err != nil {
 		return nil, err
 	}
 
-	events, err := s.eventStore.Load(ctx, accountID)
+	summary, err := s.summaryStore.FindByID(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	totalDeposits := int64(0)
-	totalWithdrawals := int64(0)
-	transactionCount := 0
+	response := summary.Response()
+	return &response, nil
+}
 
-	for _, event := range events {
-		switch event.GetEventType() {
-		case string(domain.MoneyDeposited):
-			totalDeposits += event.Amount
-			transactionCount++
-		case string(domain.MoneyWithdrawn):
-			totalWithdrawals += event.Amount
-			transactionCount++
-		}
+// ListAccounts 모든 계정 조회
+func (s *AccountQueryService) ListAccounts(ctx context.Context, minPosition int64) ([]domain.AccountResponse, error) {
+	if err := s.waitFor(ctx, minPosition); err != nil {
+		return nil, err
 	}
 
-	//이벤트 히스토리를 활용하여 계정의 추가 정보 제공
-	//총 입금액, 총 출금액, 트랜잭션 횟수 등의 추가 정보 계산
-	//이벤트 소싱의 장점 활용 (상태뿐만 아니라 상태 변경 이력 활용)
-
-	return &domain.AccountResponse{
-		ID:               account.ID,
-		Balance:          account.Balance,
-		CreatedAt:        account.CreatedAt,
-		UpdatedAt:        account.UpdatedAt,
-		UserName:         account.UserName,
-		TotalDeposits:    totalDeposits,
-		TotalWithdrawals: totalWithdrawals,
-		TransactionCount: transactionCount,
-	}, nil
+	summaries, err := s.summaryStore.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]domain.AccountResponse, 0, len(summaries))
+	for _, summary := range summaries {
+		responses = append(responses, summary.Response())
+	}
+	return responses, nil
 }
 
-// ListAccounts 모든 계정 조회
-func (s *AccountQueryService) ListAccounts(ctx context.Context) ([]domain.AccountResponse, error) {
-	accounts, err := s.accountStore.ListAll(ctx)
+// GetAccountHistory 계정의 이벤트 히스토리 중 query 범위만 조회
+// Limit 이 없거나 domain.StreamPageSize 보다 크면 StreamPageSize 개까지만 반환
+// 나머지는 마지막 이벤트의 다음 버전을 FromVersion 으로 넘겨서 이어 읽음
+func (s *AccountQueryService) GetAccountHistory(ctx context.Context, accountID string, query domain.EventQuery) ([]domain.Event, error) {
+	// 계정 존재 여부 먼저 확인
+	_, err := s.accountStore.FindByID(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 
-	var responses []domain.AccountResponse
-	for _, account := range accounts {
-		// 각 계정의 이벤트 히스토리 로드
-		events, err := s.eventStore.Load(ctx, account.ID)
-		if err != nil {
-			// 이벤트 로드 실패해도 계정 정보는 반환
-			responses = append(responses, domain.AccountResponse{
-				ID:        account.ID,
-				Balance:   account.Balance,
-				CreatedAt: account.CreatedAt,
-				UpdatedAt: account.UpdatedAt,
-				UserName:  account.UserName,
-			})
-			continue
-		}
-
-		// 이벤트 히스토리를 사용하여 추가 정보 계산
-		totalDeposits := int64(0)
-		totalWithdrawals := int64(0)
-		transactionCount := 0
-
-		for _, event := range events {
-			switch event.GetEventType() {
-			case string(domain.MoneyDeposited):
-				totalDeposits += event.Amount
-				transactionCount++
-			case string(domain.MoneyWithdrawn):
-				totalWithdrawals += event.Amount
-				transactionCount++
-			}
-		}
+	if query.Limit <= 0 || query.Limit > domain.StreamPageSize {
+		query.Limit = domain.StreamPageSize
+	}
 
-		responses = append(responses, domain.AccountResponse{
-			ID:               account.ID,
-			Balance:          account.Balance,
-			CreatedAt:        account.CreatedAt,
-			UpdatedAt:        account.UpdatedAt,
-			UserName:         account.UserName,
-			TotalDeposits:    totalDeposits,
-			TotalWithdrawals: totalWithdrawals,
-			TransactionCount: transactionCount,
-		})
+	// 이벤트 히스토리 로드
+	return s.eventStore.LoadRange(ctx, accountID, query)
+}
 
+// GetAccountAsOf asOf 이전에 일어난 이벤트만 재생해서 그 시점의 잔액과 입출금 합계를 계산
+func (s *AccountQueryService) GetAccountAsOf(ctx context.Context, accountID string, asOf time.Time) (*domain.AccountResponse, error) {
+	summary, err := s.replay(ctx, accountID, domain.EventQuery{ToTime: asOf})
+	if err != nil {
+		return nil, err
+	}
+	if summary.Version == 0 {
+		return nil, fmt.Errorf("%w: %s as of %s", domain.ErrAccountNotFound, accountID, asOf.Format(time.RFC3339))
 	}
 
-	return responses, nil
+	response := summary.Response()
+	return &response, nil
 }
 
-// GetAccountHistory 계정의 이벤트 히스토리 조회
-func (s *AccountQueryService) GetAccountHistory(ctx context.Context, accountID string) ([]domain.Event, error) {
-	// 계정 존재 여부 먼저 확인
-	_, err := s.accountStore.FindByID(ctx, accountID)
+// GetAccountAtVersion version 까지의 이벤트만 재생해서 그 버전의 잔액과 입출금 합계를 계산
+func (s *AccountQueryService) GetAccountAtVersion(ctx context.Context, accountID string, version int64) (*domain.AccountResponse, error) {
+	if version < 1 {
+		return nil, fmt.Errorf("%w: %d", domain.ErrVersionNotFound, version)
+	}
+
+	summary, err := s.replay(ctx, accountID, domain.EventQuery{ToVersion: version})
 	if err != nil {
 		return nil, err
 	}
+	if summary.Version == 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAccountNotFound, accountID)
+	}
+	if summary.Version < version {
+		return nil, fmt.Errorf("%w: %s is at version %d", domain.ErrVersionNotFound, accountID, summary.Version)
+	}
 
-	// 이벤트 히스토리 로드
-	return s.eventStore.Load(ctx, accountID)
+	response := summary.Response()
+	return &response, nil
+}
+
+// replay query 범위의 이벤트를 나눠 읽으면서 계좌 요약으로 접음 (히스토리 전체를 한 번에 메모리에 올리지 않음)
+func (s *AccountQueryService) replay(ctx context.Context, accountID string, query domain.EventQuery) (domain.AccountSummary, error) {
+	it := s.eventStore.Stream(ctx, accountID, query)
+	defer it.Close()
+
+	summary := domain.AccountSummary{AccountID: accountID}
+	for it.Next() {
+		if err := summary.Apply(it.Event()); err != nil {
+			return domain.AccountSummary{}, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return domain.AccountSummary{}, err
+	}
+	return summary, nil
+}
+
+// waitFor 계좌 요약 프로젝션의 체크포인트가 minPosition 에 닿을 때까지 기다림 (0 이하면 기다리지 않음)
+func (s *AccountQueryService) waitFor(ctx context.Context, minPosition int64) error {
+	if minPosition <= 0 {
+		return nil
+	}
+
+	deadline := time.Now().Add(s.catchUpTimeout)
+	for {
+		position, err := s.checkpoints.Load(ctx, projection.AccountSummaryProjectionName)
+		if err != nil {
+			return err
+		}
+		if position >= minPosition {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("%w: waiting for position %d, read model is at %d",
+				domain.ErrReadModelBehind, minPosition, position)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(catchUpPollInterval, time.Until(deadline))):
+		}
+	}
 }
@@ -0,0 +1,115 @@
+package query
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/memory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountPointInTime(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewMemoryDB()
+	eventStore := memory.NewEventStore(db)
+	service := NewAccountQueryService(memory.NewAccountStore(db), memory.NewAccountSummaryStore(db),
+		memory.NewCheckpointStore(db), eventStore, time.Second)
+
+	march := func(day int) time.Time { return time.Date(2026, 3, day, 12, 0, 0, 0, time.UTC) }
+	var events []domain.Event
+	for _, e := range []struct {
+		at   time.Time
+		data domain.EventData
+	}{
+		{march(1), domain.AccountCreatedData{AccountID: "account-1", UserName: "kim", InitialBalance: 100}},
+		{march(10), domain.MoneyDepositedData{AccountID: "account-1", Amount: 50}},
+		{march(31), domain.MoneyWithdrawnData{AccountID: "account-1", Amount: 30}},
+		{march(31).AddDate(0, 0, 1), domain.MoneyDepositedData{AccountID: "account-1", Amount: 1000}},
+	} {
+		event, err := domain.NewEvent("account-1", e.data)
+		assert.NoError(t, err)
+		event.CreatedAt = e.at
+		events = append(events, event)
+	}
+	assert.NoError(t, eventStore.Save(ctx, "account-1", 0, events))
+
+	t.Run("GetAccountAsOf", func(t *testing.T) {
+		account, err := service.GetAccountAsOf(ctx, "account-1", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(120), account.Balance)
+		assert.Equal(t, int64(50), account.TotalDeposits)
+		assert.Equal(t, int64(30), account.TotalWithdrawals)
+		assert.Equal(t, 2, account.TransactionCount)
+		assert.Equal(t, int64(3), account.Version)
+		assert.True(t, account.UpdatedAt.Equal(march(31)))
+
+		// asOf 시각에 일어난 이벤트는 포함하지 않음
+		account, err = service.GetAccountAsOf(ctx, "account-1", march(10))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), account.Balance)
+
+		_, err = service.GetAccountAsOf(ctx, "account-1", march(1))
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("GetAccountAtVersion", func(t *testing.T) {
+		account, err := service.GetAccountAtVersion(ctx, "account-1", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(150), account.Balance)
+		assert.Equal(t, int64(2), account.Version)
+
+		account, err = service.GetAccountAtVersion(ctx, "account-1", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1120), account.Balance)
+
+		_, err = service.GetAccountAtVersion(ctx, "account-1", 5)
+		assert.ErrorIs(t, err, domain.ErrVersionNotFound)
+		_, err = service.GetAccountAtVersion(ctx, "account-2", 1)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
+
+func TestAccountHistory(t *testing.T) {
+	ctx := context.Background()
+	db := memory.NewMemoryDB()
+	eventStore := memory.NewEventStore(db)
+	accountStore := memory.NewAccountStore(db)
+	service := NewAccountQueryService(accountStore, memory.NewAccountSummaryStore(db),
+		memory.NewCheckpointStore(db), eventStore, time.Second)
+
+	events := make([]domain.Event, domain.StreamPageSize+10)
+	for i := range events {
+		events[i], _ = domain.NewEvent("account-1", domain.MoneyDepositedData{AccountID: "account-1", Amount: 10})
+	}
+	assert.NoError(t, eventStore.Save(ctx, "account-1", 0, events))
+	assert.NoError(t, accountStore.Create(ctx, &domain.Account{ID: "account-1", UserName: "kim", CreatedAt: time.Now()}))
+
+	t.Run("Limit 이 없거나 너무 크면 한 페이지만 반환", func(t *testing.T) {
+		history, err := service.GetAccountHistory(ctx, "account-1", domain.EventQuery{})
+		assert.NoError(t, err)
+		assert.Len(t, history, domain.StreamPageSize)
+
+		history, err = service.GetAccountHistory(ctx, "account-1", domain.EventQuery{Limit: domain.StreamPageSize * 2})
+		assert.NoError(t, err)
+		assert.Len(t, history, domain.StreamPageSize)
+
+		// 마지막 이벤트 다음 버전부터 이어 읽기
+		rest, err := service.GetAccountHistory(ctx, "account-1",
+			domain.EventQuery{FromVersion: history[len(history)-1].Version + 1})
+		assert.NoError(t, err)
+		assert.Len(t, rest, 10)
+	})
+
+	t.Run("Limit 이 있으면 그 개수만 반환", func(t *testing.T) {
+		history, err := service.GetAccountHistory(ctx, "account-1", domain.EventQuery{Limit: 3})
+		assert.NoError(t, err)
+		assert.Len(t, history, 3)
+	})
+
+	t.Run("없는 계좌", func(t *testing.T) {
+		_, err := service.GetAccountHistory(ctx, "account-2", domain.EventQuery{})
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
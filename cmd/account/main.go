@@ -6,12 +6,13 @@ import (
 	appCommand "go-eventsourcing-patterns/application/command"
 	"go-eventsourcing-patterns/application/query"
 	"go-eventsourcing-patterns/domain"
-	infraKafka "go-eventsourcing-patterns/infrastructure/kafka"
 	store "go-eventsourcing-patterns/infrastructure/persistence/postgres"
 	"go-eventsourcing-patterns/interface/http"
 	"go-eventsourcing-patterns/interface/telemetry"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 func main() {
@@ -40,21 +41,25 @@ func main() {
 
 	accountStore := store.NewAccountStore(db)
 
-	brokers := os.Getenv("KAFKA_BROKERS")
-	topic := os.Getenv("KAFKA_TOPIC")
-
-	if brokers == "" || topic == "" {
-		log.Fatalf("Empty kafka info")
-	}
-
-	eventPublisher, err := infraKafka.NewEventPublisher(brokers, topic)
-	if err != nil {
-		log.Fatalf("Failed to create event publisher: %v", err)
+	// 스냅샷 주기 (이벤트 N 개마다), 0 이면 자동 스냅샷 없음
+	snapshotEvery := int64(100)
+	if v := os.Getenv("SNAPSHOT_EVERY_N_EVENTS"); v != "" {
+		snapshotEvery, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid SNAPSHOT_EVERY_N_EVENTS: %v", err)
+		}
 	}
 
 	eventStore := store.NewEventStore(db)
-	commandService := appCommand.NewAccountCommandService(accountStore, eventStore, eventPublisher, db)
-	queryService := query.NewAccountQueryService(accountStore, eventStore)
+	snapshotStore := store.NewSnapshotStore(db)
+	// 이벤트는 outbox 에 기록되고 Kafka 발행은 cmd/outbox-relay 가 담당
+	outboxStore := store.NewOutboxStore(db)
+	commandService := appCommand.NewAccountCommandService(accountStore, eventStore,
+		snapshotStore, domain.SnapshotEvery(snapshotEvery), outboxStore, db)
+	// 조회는 cmd/event 가 실행하는 프로젝션이 만든 account_summaries 에서 읽음
+	// X-Min-Position 을 받으면 최대 3초까지 프로젝션이 따라잡기를 기다림
+	queryService := query.NewAccountQueryService(accountStore, store.NewAccountSummaryStore(db),
+		store.NewCheckpointStore(db), eventStore, 3*time.Second)
 
 	accountHandler := http.NewAccountHandler(commandService, queryService)
 
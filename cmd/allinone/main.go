@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"github.com/gin-gonic/gin"
+	appCommand "go-eventsourcing-patterns/application/command"
+	"go-eventsourcing-patterns/application/notification"
+	"go-eventsourcing-patterns/application/outbox"
+	"go-eventsourcing-patterns/application/projection"
+	"go-eventsourcing-patterns/application/query"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/eventbus"
+	"go-eventsourcing-patterns/infrastructure/eventhandler"
+	infraNotification "go-eventsourcing-patterns/infrastructure/notification"
+	"go-eventsourcing-patterns/infrastructure/persistence/memory"
+	"go-eventsourcing-patterns/infrastructure/persistence/sqlite"
+	"go-eventsourcing-patterns/interface/http"
+	"log"
+	nethttp "net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// API 와 이벤트 처리를 한 프로세스에서 실행 (Kafka, Postgres 없이 로컬 개발/데모용)
+// 커맨드가 outbox 에 기록한 이벤트를 릴레이가 Kafka 대신 프로세스 내부 이벤트 버스로 발행
+//
+//	allinone [-addr :8080] [-snapshot-every 100] [-sqlite path/to/eventstore.db]
+func main() {
+	addr := flag.String("addr", ":8080", "http listen address")
+	snapshotEvery := flag.Int64("snapshot-every", 100, "take a snapshot every N events (0 disables)")
+	sqlitePath := flag.String("sqlite", "", "sqlite database file (in-memory store if empty)")
+	flag.Parse()
+
+	var (
+		accountStore  domain.AccountStore
+		eventStore    domain.EventStore
+		snapshotStore domain.SnapshotStore
+		outboxStore   domain.Outbox
+		summaryStore  domain.AccountSummaryStore
+		checkpoints   domain.CheckpointStore
+		txManager     domain.TransactionManager
+		storeName     string
+	)
+	if *sqlitePath == "" {
+		db := memory.NewMemoryDB()
+		defer db.Close()
+
+		accountStore, eventStore = memory.NewAccountStore(db), memory.NewEventStore(db)
+		snapshotStore, outboxStore = memory.NewSnapshotStore(db), memory.NewOutboxStore(db)
+		summaryStore, checkpoints = memory.NewAccountSummaryStore(db), memory.NewCheckpointStore(db)
+		txManager, storeName = db, "in-memory store"
+	} else {
+		db, err := sqlite.NewSQLiteDB(*sqlitePath)
+		if err != nil {
+			log.Fatalf("Failed to open sqlite database: %v", err)
+		}
+		defer db.Close()
+
+		accountStore, eventStore = sqlite.NewAccountStore(db), sqlite.NewEventStore(db)
+		snapshotStore, outboxStore = sqlite.NewSnapshotStore(db), sqlite.NewOutboxStore(db)
+		summaryStore, checkpoints = sqlite.NewAccountSummaryStore(db), sqlite.NewCheckpointStore(db)
+		txManager, storeName = db, "sqlite store "+*sqlitePath
+	}
+
+	commandService := appCommand.NewAccountCommandService(accountStore, eventStore,
+		snapshotStore, domain.SnapshotEvery(*snapshotEvery), outboxStore, txManager)
+	queryService := query.NewAccountQueryService(accountStore, summaryStore, checkpoints, eventStore, 3*time.Second)
+
+	// 계좌별 순서를 지키면서 비동기로 구독자에게 전달하는 이벤트 버스
+	bus := eventbus.NewInMemoryEventBus(
+		eventbus.WithAsync(eventbus.DefaultAsyncConfig),
+		eventbus.WithMiddleware(eventhandler.Tracing(), eventhandler.Metrics()))
+	bus.SubscribeNamed(domain.WildcardEventType, "event-log", domain.EventHandlerFunc(logEvent))
+
+	// cmd/event 의 Kafka 컨슈머와 같은 계좌 활동 알림 핸들러를 버스에 구독
+	// (인박스 멱등 처리는 Kafka 패키지에 있어서 쓰지 않음, 릴레이가 같은 메시지를 다시 발행하면 알림이 중복될 수 있음)
+	notifier := infraNotification.NewLogNotifier()
+	bus.SubscribeNamed(string(domain.AccountCreated), "account-created", notification.NewAccountCreatedHandler(notifier))
+	bus.SubscribeNamed(string(domain.MoneyDeposited), "money-deposited", notification.NewMoneyDepositHandler(notifier))
+	bus.SubscribeNamed(string(domain.MoneyWithdrawn), "money-withdrawn", notification.NewMoneyWithdrawHandler(notifier))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relay := outbox.NewRelay(outboxStore, bus, txManager, 100, 100*time.Millisecond)
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(ctx)
+	}()
+
+	// 조회 모델 프로젝션은 버스에 구독하지 않고 체크포인트를 두고 이벤트 로그를 폴링
+	// 버스 전달은 저장되지 않아 재시작하거나 프로젝션을 다시 만들 때 처음부터 다시 읽을 수 없기 때문 (cmd/event 와 같은 방식)
+	runner := projection.NewRunner(eventStore, checkpoints, txManager, 500, 50*time.Millisecond)
+	if err := runner.Register(projection.NewAccountSummaryProjection(summaryStore)); err != nil {
+		log.Fatalf("Failed to register projection: %v", err)
+	}
+	if err := runner.Start(ctx); err != nil {
+		log.Fatalf("Failed to start projections: %v", err)
+	}
+
+	router := gin.Default()
+	http.NewAccountHandler(commandService, queryService).SetupRoutes(router)
+	server := &nethttp.Server{Addr: *addr, Handler: router}
+
+	go func() {
+		log.Printf("All-in-one server started on %s (%s)", *addr, storeName)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			log.Fatalf("Failed to run server: %v", err)
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+	log.Println("Shutting down...")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down server: %v", err)
+	}
+
+	// 릴레이와 프로젝션을 멈춘 뒤 버스에 남은 이벤트를 모두 처리하고 종료
+	cancel()
+	<-relayDone
+	runner.Stop()
+	bus.Close()
+}
+
+// logEvent 발행된 이벤트와 페이로드를 로그로 출력
+func logEvent(ctx context.Context, event domain.Event) error {
+	data, err := event.DecodeData()
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	log.Printf("Event: Type=%s, AccountID=%s, Version=%d, Payload=%s",
+		event.EventType, event.AccountID, event.Version, payload)
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	infraKafka "go-eventsourcing-patterns/infrastructure/kafka"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// 데드레터 토픽 관리 명령어
+//
+//	dlq list [-event-type T] [-account A]
+//	dlq show -position P:O
+//	dlq replay (-positions P:O,P:O | -event-type T | -account A | -all)
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	brokers := os.Getenv("KAFKA_BROKERS")
+	topic := os.Getenv("KAFKA_TOPIC")
+
+	if brokers == "" || topic == "" {
+		log.Fatalf("Empty kafka info")
+	}
+
+	dlqTopic := os.Getenv("KAFKA_DLQ_TOPIC")
+	if dlqTopic == "" {
+		dlqTopic = topic + ".dlq"
+	}
+
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	eventType := fs.String("event-type", "", "filter by event type")
+	accountID := fs.String("account", "", "filter by account id")
+	position := fs.String("position", "", "dead letter position (partition:offset) to show")
+	positions := fs.String("positions", "", "comma separated dead letter positions (partition:offset) to replay")
+	all := fs.Bool("all", false, "replay every dead letter")
+	timeout := fs.Duration("timeout", 10*time.Second, "kafka request timeout")
+	fs.Parse(os.Args[2:])
+
+	filter := infraKafka.DeadLetterFilter{
+		EventType: *eventType,
+		AccountID: *accountID,
+	}
+
+	switch os.Args[1] {
+	case "list":
+		for _, m := range readDeadLetters(brokers, dlqTopic, *timeout, filter) {
+			eventType, accountID := "-", "-"
+			if m.Event != nil {
+				eventType, accountID = m.Event.EventType, m.Event.AccountID
+			}
+			fmt.Printf("%-10s %-16s %-38s attempts=%d failed_at=%s error=%q\n",
+				m.Position(), eventType, accountID, m.Attempts, m.FailedAt.Format(time.RFC3339), m.Error)
+		}
+
+	case "show":
+		if *position == "" {
+			log.Fatalf("-position is required")
+		}
+		filter.Positions = []string{*position}
+		messages := readDeadLetters(brokers, dlqTopic, *timeout, filter)
+		if len(messages) == 0 {
+			log.Fatalf("No dead letter at %s", *position)
+		}
+		show(messages[0])
+
+	case "replay":
+		if *positions != "" {
+			filter.Positions = strings.Split(*positions, ",")
+		}
+		if len(filter.Positions) == 0 && filter.EventType == "" && filter.AccountID == "" && !*all {
+			log.Fatalf("Select messages with -positions, -event-type, -account or -all")
+		}
+
+		replayer, err := infraKafka.NewDeadLetterReplayer(brokers, topic)
+		if err != nil {
+			log.Fatalf("Failed to create replayer: %v", err)
+		}
+		defer replayer.Close()
+
+		replayed := 0
+		for _, m := range readDeadLetters(brokers, dlqTopic, *timeout, filter) {
+			if err := replayer.Replay(m); err != nil {
+				log.Fatalf("Failed to replay %s: %v", m.Position(), err)
+			}
+			log.Printf("Replayed %s to %s", m.Position(), topic)
+			replayed++
+		}
+		log.Printf("Replayed %d dead letters", replayed)
+
+	default:
+		usage()
+	}
+}
+
+func readDeadLetters(brokers, dlqTopic string, timeout time.Duration, filter infraKafka.DeadLetterFilter) []infraKafka.DeadLetterMessage {
+	reader, err := infraKafka.NewDeadLetterReader(brokers, dlqTopic)
+	if err != nil {
+		log.Fatalf("Failed to create dead letter reader: %v", err)
+	}
+	defer reader.Close()
+
+	messages, err := reader.ReadAll(timeout)
+	if err != nil {
+		log.Fatalf("Failed to read dead letters: %v", err)
+	}
+
+	var matched []infraKafka.DeadLetterMessage
+	for _, m := range messages {
+		if filter.Matches(m) {
+			matched = append(matched, m)
+		}
+	}
+	return matched
+}
+
+func show(m infraKafka.DeadLetterMessage) {
+	fmt.Printf("Position:    %s\n", m.Position())
+	fmt.Printf("Original:    %s [%d] @ %d\n", m.OriginalTopic, m.OriginalPartition, m.OriginalOffset)
+	fmt.Printf("Attempts:    %d\n", m.Attempts)
+	fmt.Printf("Failed at:   %s\n", m.FailedAt.Format(time.RFC3339))
+	fmt.Printf("Error:       %s\n", m.Error)
+
+	if m.DecodeError != nil {
+		fmt.Printf("Decode error: %v\n", m.DecodeError)
+	}
+	if m.Event == nil {
+		return
+	}
+
+	event, _ := json.MarshalIndent(m.Event, "", "  ")
+	fmt.Printf("Event:\n%s\n", event)
+	if data, err := m.Event.DecodeData(); err == nil {
+		payload, _ := json.MarshalIndent(data, "", "  ")
+		fmt.Printf("Payload (%s):\n%s\n", m.Event.EventType, payload)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dlq <list|show|replay> [flags]")
+	os.Exit(2)
+}
@@ -2,13 +2,18 @@ package main
 
 import (
 	"context"
+	"go-eventsourcing-patterns/application/notification"
+	"go-eventsourcing-patterns/application/projection"
 	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/eventhandler"
 	infraKafka "go-eventsourcing-patterns/infrastructure/kafka"
+	infraNotification "go-eventsourcing-patterns/infrastructure/notification"
 	store "go-eventsourcing-patterns/infrastructure/persistence/postgres"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func main() {
@@ -37,8 +42,20 @@ func main() {
 	// 환경변수 로깅 추가
 	log.Printf("Kafka configuration - Brokers: %s, Topic: %s, GroupID: %s", brokers, topic, groupId)
 
+	// 재시도를 모두 실패한 메시지를 보낼 데드레터 토픽
+	dlqTopic := os.Getenv("KAFKA_DLQ_TOPIC")
+	if dlqTopic == "" {
+		dlqTopic = topic + ".dlq"
+	}
+	deadLetters, err := infraKafka.NewDeadLetterPublisher(brokers, dlqTopic)
+	if err != nil {
+		log.Fatalf("Failed to create dead letter publisher: %v", err)
+	}
+	defer deadLetters.Close()
+
 	// 순서 확인 (brokers, groupId, topic)
-	consumer, err := infraKafka.NewEventConsumer(brokers, groupId, topic)
+	consumer, err := infraKafka.NewEventConsumer(brokers, groupId, topic,
+		infraKafka.WithDeadLetterQueue(deadLetters))
 	if err != nil {
 		log.Fatalf("Failed to create consumer: %v", err)
 	}
@@ -47,13 +64,33 @@ func main() {
 	log.Printf("Attempting to subscribe to topic: %s", topic)
 	defer consumer.Close()
 
-	accountCreatedHandler := infraKafka.NewAccountCreatedHandler(eventStore)
-	moneyDepositedHandler := infraKafka.NewMoneyDepositHandler(eventStore)
-	moneyWithdrawnHandler := infraKafka.NewMoneyWithdrawHandler(eventStore)
+	// 계좌 활동 알림, 재전달된 이벤트로 알림이 두 번 가지 않도록 인박스 멱등 핸들러로 감싸서 등록
+	inboxStore := store.NewInboxStore(db)
+	notifier := infraNotification.NewLogNotifier()
+	accountCreatedHandler := infraKafka.NewIdempotentHandler("account-created",
+		notification.NewAccountCreatedHandler(notifier), inboxStore, db)
+	moneyDepositedHandler := infraKafka.NewIdempotentHandler("money-deposited",
+		notification.NewMoneyDepositHandler(notifier), inboxStore, db)
+	moneyWithdrawnHandler := infraKafka.NewIdempotentHandler("money-withdrawn",
+		notification.NewMoneyWithdrawHandler(notifier), inboxStore, db)
+
+	// 모든 핸들러 실행을 추적/로깅/메트릭 미들웨어로 감쌈 (재시도와 panic 복구는 컨슈머가 핸들러마다 적용)
+	consumer.Use(eventhandler.Tracing(), eventhandler.Logging(), eventhandler.Metrics())
 
-	consumer.RegisterHandler(string(domain.AccountCreated), accountCreatedHandler)
-	consumer.RegisterHandler(string(domain.MoneyDeposited), moneyDepositedHandler)
-	consumer.RegisterHandler(string(domain.MoneyWithdrawn), moneyWithdrawnHandler)
+	handlers := []struct {
+		eventType domain.EventType
+		name      string
+		handler   domain.EventHandler
+	}{
+		{domain.AccountCreated, "account-created", accountCreatedHandler},
+		{domain.MoneyDeposited, "money-deposited", moneyDepositedHandler},
+		{domain.MoneyWithdrawn, "money-withdrawn", moneyWithdrawnHandler},
+	}
+	for _, h := range handlers {
+		if err := consumer.RegisterHandler(string(h.eventType), h.name, h.handler); err != nil {
+			log.Fatalf("Failed to register handler: %v", err)
+		}
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -67,7 +104,17 @@ func main() {
 
 	log.Println("Event consumer started successfully")
 
+	// 이벤트 로그를 읽어서 조회 모델을 만드는 프로젝션 (프로젝션마다 이 프로세스 하나에서만 실행)
+	runner := projection.NewRunner(eventStore, store.NewCheckpointStore(db), db, 500, 200*time.Millisecond)
+	if err := runner.Register(projection.NewAccountSummaryProjection(store.NewAccountSummaryStore(db))); err != nil {
+		log.Fatalf("Failed to register projection: %v", err)
+	}
+	if err := runner.Start(ctx); err != nil {
+		log.Fatalf("Failed to start projections: %v", err)
+	}
+
 	//시그널 대기
 	<-sigChan
 	log.Println("Shutting down...")
+	runner.Stop()
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"go-eventsourcing-patterns/application/outbox"
+	"go-eventsourcing-patterns/domain"
+	infraKafka "go-eventsourcing-patterns/infrastructure/kafka"
+	store "go-eventsourcing-patterns/infrastructure/persistence/postgres"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+
+	db, err := store.NewPostgresDB(&domain.Config{
+		DBHost:     "postgres", // docker 서비스명
+		DBPort:     "5432",
+		DBUser:     "user",
+		DBPassword: "password",
+		DBName:     "eventstore",
+		SSLMode:    "disable", // 로컬 개발환경이므로 SSL 비활성화
+	})
+	if err != nil {
+		log.Fatalf("Failed to conncting databse: %v", err)
+	}
+	defer db.Close()
+
+	brokers := os.Getenv("KAFKA_BROKERS")
+	topic := os.Getenv("KAFKA_TOPIC")
+
+	if brokers == "" || topic == "" {
+		log.Fatalf("Empty kafka info")
+	}
+
+	eventPublisher, err := infraKafka.NewEventPublisher(brokers, topic)
+	if err != nil {
+		log.Fatalf("Failed to create event publisher: %v", err)
+	}
+	defer eventPublisher.Close()
+
+	relay := outbox.NewRelay(store.NewOutboxStore(db), eventPublisher, db, 100, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		log.Println("Shutting down...")
+		cancel()
+	}()
+
+	log.Printf("Outbox relay started - Brokers: %s, Topic: %s", brokers, topic)
+	relay.Run(ctx)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-eventsourcing-patterns/application/projection"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/postgres"
+	"go-eventsourcing-patterns/infrastructure/persistence/sqlite"
+	"log"
+	"os"
+	"time"
+)
+
+// 프로젝션 관리 명령어
+// rebuild, reset, replay 는 해당 프로젝션을 실행 중인 프로세스(cmd/event)를 멈춘 뒤 실행해야 함
+// rebuild -shadow 는 임시 테이블에 다시 만든 뒤 교체하므로 실행 중에도 사용할 수 있음
+//
+//	projections status
+//	projections rebuild [-shadow] <name>
+//	projections reset [<name>...]
+//	projections replay -from <position> <name>
+//
+// 플래그는 이름 앞뒤 어디에 써도 됨 (rebuild account-summaries -shadow 도 같은 뜻)
+//
+// replay 는 position 이후에 이벤트가 있는 계좌의 조회 모델만 지우고 그 계좌들을 처음부터 다시 반영
+// position 은 프로젝션의 현재 체크포인트(status 로 확인) 이하여야 함
+//
+// 기본은 Postgres (POSTGRES_HOST, 기본값 postgres), -sqlite 를 주면 allinone 의 SQLite 파일을 사용
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	sqlitePath := fs.String("sqlite", "", "sqlite database file (postgres if empty)")
+	shadow := fs.Bool("shadow", false, "rebuild into a shadow table and swap it in when caught up")
+	from := fs.Int64("from", -1, "global position to replay from (exclusive)")
+	batchSize := fs.Int("batch-size", 500, "events per transaction")
+	names := parseArgs(fs, os.Args[2:])
+
+	runner, closeDB := newRunner(*sqlitePath, *batchSize)
+	defer closeDB()
+
+	ctx := context.Background()
+	started := time.Now()
+
+	switch os.Args[1] {
+	case "status":
+		if len(names) > 0 {
+			log.Fatalf("status takes no projection name")
+		}
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read projection status: %v", err)
+		}
+		for _, s := range statuses {
+			updatedAt := "-"
+			if !s.UpdatedAt.IsZero() {
+				updatedAt = s.UpdatedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%-24s position=%d updated_at=%s\n", s.Name, s.Position, updatedAt)
+		}
+
+	case "rebuild":
+		name := projectionName(names)
+		rebuild := runner.Rebuild
+		if *shadow {
+			rebuild = runner.RebuildShadow
+		}
+		n, err := rebuild(ctx, name)
+		if err != nil {
+			log.Fatalf("Failed to rebuild %s after %d events: %v", name, n, err)
+		}
+		log.Printf("Rebuilt %s from %d events in %s", name, n, time.Since(started))
+
+	case "reset":
+		if len(names) == 0 {
+			statuses, err := runner.Status(ctx)
+			if err != nil {
+				log.Fatalf("Failed to read projection status: %v", err)
+			}
+			for _, s := range statuses {
+				names = append(names, s.Name)
+			}
+		}
+		for _, name := range names {
+			if err := runner.Reset(ctx, name); err != nil {
+				log.Fatalf("Failed to reset %s: %v", name, err)
+			}
+			log.Printf("Reset %s", name)
+		}
+
+	case "replay":
+		name := projectionName(names)
+		if *from < 0 {
+			log.Fatalf("-from is required")
+		}
+		n, err := runner.Replay(ctx, name, *from)
+		if err != nil {
+			log.Fatalf("Failed to replay %s after %d events: %v", name, n, err)
+		}
+		log.Printf("Replayed %d events after position %d through %s in %s", n, *from, name, time.Since(started))
+
+	default:
+		usage()
+	}
+}
+
+// newRunner 저장소를 열고 모든 프로젝션을 등록한 러너를 만듦 (Start 하지 않음)
+func newRunner(sqlitePath string, batchSize int) (*projection.Runner, func()) {
+	var (
+		eventStore  domain.EventStore
+		checkpoints domain.CheckpointStore
+		summaries   domain.AccountSummaryStore
+		txManager   domain.TransactionManager
+		closeDB     func()
+	)
+	if sqlitePath == "" {
+		host := os.Getenv("POSTGRES_HOST")
+		if host == "" {
+			host = "postgres" // docker 서비스명
+		}
+		db, err := postgres.NewPostgresDB(&domain.Config{
+			DBHost:     host,
+			DBPort:     "5432",
+			DBUser:     "user",
+			DBPassword: "password",
+			DBName:     "eventstore",
+			SSLMode:    "disable",
+		})
+		if err != nil {
+			log.Fatalf("Failed to connect to postgres: %v", err)
+		}
+		eventStore, checkpoints = postgres.NewEventStore(db), postgres.NewCheckpointStore(db)
+		summaries, txManager = postgres.NewAccountSummaryStore(db), db
+		closeDB = func() { db.Close() }
+	} else {
+		db, err := sqlite.NewSQLiteDB(sqlitePath)
+		if err != nil {
+			log.Fatalf("Failed to open sqlite database: %v", err)
+		}
+		eventStore, checkpoints = sqlite.NewEventStore(db), sqlite.NewCheckpointStore(db)
+		summaries, txManager = sqlite.NewAccountSummaryStore(db), db
+		closeDB = func() { db.Close() }
+	}
+
+	runner := projection.NewRunner(eventStore, checkpoints, txManager, batchSize, time.Second)
+	if err := runner.Register(projection.NewAccountSummaryProjection(summaries)); err != nil {
+		log.Fatalf("Failed to register projection: %v", err)
+	}
+	return runner, closeDB
+}
+
+// parseArgs 플래그를 파싱하고 프로젝션 이름들을 반환
+// flag 패키지는 첫 번째 위치 인자에서 파싱을 멈추므로 이름 뒤에 남은 인자를 다시 파싱해서 플래그가 무시되지 않게 함
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var names []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			return names
+		}
+		names = append(names, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func projectionName(names []string) string {
+	if len(names) != 1 {
+		log.Fatalf("Exactly one projection name is required")
+	}
+	return names[0]
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: projections <status|rebuild|reset|replay> [flags] [name...] [flags]")
+	os.Exit(2)
+}
@@ -1,4 +1,5 @@
 -- deployments/postgres/init.sql
+-- accounts 는 events 로부터 파생되는 프로젝션
 CREATE TABLE IF NOT EXISTS accounts (
                                         id        VARCHAR(100) PRIMARY KEY,
                                         user_name VARCHAR(255) NOT NULL,
@@ -9,12 +10,69 @@ CREATE TABLE IF NOT EXISTS accounts (
 
 CREATE TABLE IF NOT EXISTS events (
                                       id         VARCHAR(100) PRIMARY KEY,
-                                      account_id VARCHAR(100) NOT NULL REFERENCES accounts(id),
+                                      account_id VARCHAR(100) NOT NULL,
                                       event_type VARCHAR(255) NOT NULL,
                                       event_data JSONB NOT NULL,
-                                      created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+                                      schema_version INT NOT NULL DEFAULT 1,
+                                      version    BIGINT NOT NULL,
+                                      -- 전체 이벤트 로그 내 순번 (ReadAll 로 순서대로 따라 읽기 위함)
+                                      position   BIGSERIAL NOT NULL,
+                                      created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                      -- 스트림 내 버전 중복을 막아 낙관적 동시성 제어
+                                      CONSTRAINT uq_events_account_version UNIQUE (account_id, version),
+                                      CONSTRAINT uq_events_position UNIQUE (position)
                              );
 
 
 CREATE INDEX idx_events_account_id ON events(account_id);
 
+
+-- 계좌별 최신 애그리거트 스냅샷
+CREATE TABLE IF NOT EXISTS snapshots (
+                                         account_id VARCHAR(100) PRIMARY KEY,
+                                         version    BIGINT NOT NULL,
+                                         state      JSONB NOT NULL,
+                                         created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 트랜잭셔널 아웃박스: 커맨드 트랜잭션 안에서 기록되고 outbox-relay 가 순서대로 Kafka 로 발행
+CREATE TABLE IF NOT EXISTS outbox (
+                                      id         BIGSERIAL PRIMARY KEY,
+                                      event_id   VARCHAR(100) NOT NULL,
+                                      account_id VARCHAR(100) NOT NULL,
+                                      payload    JSONB NOT NULL,
+                                      created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                      sent_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(id) WHERE sent_at IS NULL;
+
+-- 컨슈머 인박스: 핸들러별로 처리한 이벤트 ID 를 핸들러의 쓰기와 같은 트랜잭션으로 기록
+CREATE TABLE IF NOT EXISTS inbox (
+                                     handler_name VARCHAR(255) NOT NULL,
+                                     event_id     VARCHAR(100) NOT NULL,
+                                     processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                     PRIMARY KEY (handler_name, event_id)
+);
+
+-- 프로젝션 체크포인트: 프로젝션이 반영한 마지막 이벤트 position 을 프로젝션의 쓰기와 같은 트랜잭션으로 기록
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+                                                      projection_name VARCHAR(255) PRIMARY KEY,
+                                                      position        BIGINT NOT NULL,
+                                                      updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 계좌 요약 조회 모델: account-summaries 프로젝션이 이벤트 스트림에서 만듦
+CREATE TABLE IF NOT EXISTS account_summaries (
+                                                 account_id        VARCHAR(100) PRIMARY KEY,
+                                                 user_name         VARCHAR(255) NOT NULL DEFAULT '',
+                                                 balance           BIGINT NOT NULL DEFAULT 0,
+                                                 total_deposits    BIGINT NOT NULL DEFAULT 0,
+                                                 total_withdrawals BIGINT NOT NULL DEFAULT 0,
+                                                 transaction_count INTEGER NOT NULL DEFAULT 0,
+                                                 created_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+                                                 last_activity_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+                                                 version           BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_account_summaries_created_at ON account_summaries(created_at, account_id);
@@ -59,6 +59,8 @@ type WithdrawRequest struct {
 
 type GetAccountRequest struct {
 	AccountId string `json:"account_id" form:"account_id"`
+	AsOf      string `json:"as_of" form:"as_of"`     // RFC3339 시각 또는 날짜(2006-01-02), 그 시점의 상태를 조회
+	Version   int64  `json:"version" form:"version"` // 스트림 버전, 그 버전까지의 상태를 조회
 }
 
 type AccountResponse struct {
@@ -71,21 +73,37 @@ type AccountResponse struct {
 	TotalDeposits    int64 `json:"total_deposits"`
 	TotalWithdrawals int64 `json:"total_withdrawals"`
 	TransactionCount int   `json:"transaction_count"`
+	Version          int64 `json:"version"` // 이 상태를 만든 마지막 이벤트의 스트림 버전
+}
+
+// CommandResult 커맨드가 저장한 이벤트의 위치
+// 조회할 때 Position 을 최소 position 으로 넘기면 조회 모델이 이 커맨드를 반영한 뒤의 결과를 받음
+type CommandResult struct {
+	AccountID string `json:"account_id"`
+	Version   int64  `json:"version"`  // 계좌 스트림의 새 버전
+	Position  int64  `json:"position"` // 마지막으로 저장한 이벤트의 전체 로그 position
 }
 
 //go:generate mockgen -source=account.go -destination=mock/mock_account.go -package=mock
 
 // Account 서비스 인터페이스
 type AccountCommandService interface {
-	CreateAccount(ctx context.Context, cmd CreateAccountCommand) error
-	Deposit(ctx context.Context, cmd DepositCommand) error
-	Withdraw(ctx context.Context, cmd WithdrawCommand) error
+	CreateAccount(ctx context.Context, cmd CreateAccountCommand) (CommandResult, error)
+	Deposit(ctx context.Context, cmd DepositCommand) (CommandResult, error)
+	Withdraw(ctx context.Context, cmd WithdrawCommand) (CommandResult, error)
 }
 
+// AccountQueryService minPosition 이 0 보다 크면 조회 모델이 그 position 까지 반영할 때까지 기다린 뒤 조회
+// 제한 시간 안에 따라잡지 못하면 ErrReadModelBehind
 type AccountQueryService interface {
-	GetAccountByID(ctx context.Context, accountID string) (*AccountResponse, error)
-	ListAccounts(ctx context.Context) ([]AccountResponse, error)
-	GetAccountHistory(ctx context.Context, accountID string) ([]Event, error)
+	GetAccountByID(ctx context.Context, accountID string, minPosition int64) (*AccountResponse, error)
+	ListAccounts(ctx context.Context, minPosition int64) ([]AccountResponse, error)
+	// GetAccountHistory 계좌 이벤트 히스토리 중 query 범위만 조회, 한 번에 최대 StreamPageSize 개
+	GetAccountHistory(ctx context.Context, accountID string, query EventQuery) ([]Event, error)
+	// GetAccountAsOf asOf 이전에 일어난 이벤트만 재생한 계좌 상태, 그때 계좌가 없었으면 ErrAccountNotFound
+	GetAccountAsOf(ctx context.Context, accountID string, asOf time.Time) (*AccountResponse, error)
+	// GetAccountAtVersion version 까지의 이벤트만 재생한 계좌 상태, 그 버전이 없으면 ErrVersionNotFound
+	GetAccountAtVersion(ctx context.Context, accountID string, version int64) (*AccountResponse, error)
 }
 
 // Account 저장소 인터페이스
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AccountAggregate 이벤트 스트림으로부터 재구성되는 계좌 애그리거트
+// 상태 변경은 Decide 로 이벤트를 만들고 Apply 로 반영하는 방식으로만 일어난다
+type AccountAggregate struct {
+	ID        string    `json:"id"`
+	UserName  string    `json:"user_name"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Version 마지막으로 적용된 이벤트의 버전 (= 적용된 이벤트 수)
+	Version int64 `json:"version"`
+}
+
+// NewAccountAggregate 아직 이벤트가 없는 빈 애그리거트 생성
+func NewAccountAggregate(accountID string) *AccountAggregate {
+	return &AccountAggregate{ID: accountID}
+}
+
+// RehydrateAccount 저장된 이벤트들을 순서대로 적용해서 애그리거트 복원
+func RehydrateAccount(accountID string, events []Event) (*AccountAggregate, error) {
+	aggregate := NewAccountAggregate(accountID)
+	for _, event := range events {
+		if err := aggregate.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return aggregate, nil
+}
+
+// Exists AccountCreated 이벤트가 적용되었는지 여부
+func (a *AccountAggregate) Exists() bool {
+	return a.Version > 0
+}
+
+// Apply 이벤트 하나를 상태에 반영 (검증 없이 이미 일어난 사실로 취급)
+func (a *AccountAggregate) Apply(event Event) error {
+	data, err := event.DecodeData()
+	if err != nil {
+		return err
+	}
+
+	switch d := data.(type) {
+	case AccountCreatedData:
+		a.ID = event.AccountID
+		a.UserName = d.UserName
+		a.Balance = d.InitialBalance
+		a.CreatedAt = event.CreatedAt
+	case MoneyDepositedData:
+		a.Balance += d.Amount
+	case MoneyWithdrawnData:
+		a.Balance -= d.Amount
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownEventType, event.EventType)
+	}
+
+	a.UpdatedAt = event.CreatedAt
+	if event.Version > 0 {
+		a.Version = event.Version
+	} else {
+		a.Version++
+	}
+	return nil
+}
+
+// Decide 현재 상태에서 커맨드를 검증하고 발생할 이벤트들을 반환 (상태는 바꾸지 않음)
+func (a *AccountAggregate) Decide(cmd interface{}) ([]Event, error) {
+	switch c := cmd.(type) {
+	case CreateAccountCommand:
+		if a.Exists() {
+			return nil, ErrAccountAlreadyExists
+		}
+		if c.InitialBalance < 0 {
+			return nil, ErrInvalidAmount
+		}
+		return a.newEvents(AccountCreatedData{
+			AccountID:      a.ID,
+			UserName:       c.UserName,
+			InitialBalance: c.InitialBalance,
+		})
+	case DepositCommand:
+		if !a.Exists() {
+			return nil, ErrAccountNotFound
+		}
+		if c.Amount <= 0 {
+			return nil, ErrInvalidAmount
+		}
+		return a.newEvents(MoneyDepositedData{
+			AccountID:       a.ID,
+			Amount:          c.Amount,
+			OriginalBalance: a.Balance,
+		})
+	case WithdrawCommand:
+		if !a.Exists() {
+			return nil, ErrAccountNotFound
+		}
+		if c.Amount <= 0 {
+			return nil, ErrInvalidAmount
+		}
+		if a.Balance < c.Amount {
+			return nil, ErrInsufficientBalance
+		}
+		return a.newEvents(MoneyWithdrawnData{
+			AccountID:       a.ID,
+			Amount:          c.Amount,
+			OriginalBalance: a.Balance,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported command: %T", cmd)
+	}
+}
+
+// ToAccount 애그리거트 상태를 accounts 프로젝션 행으로 변환
+func (a *AccountAggregate) ToAccount() *Account {
+	return &Account{
+		ID:        a.ID,
+		Balance:   a.Balance,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+		UserName:  a.UserName,
+	}
+}
+
+// newEvents 페이로드들로 현재 버전 다음부터 번호가 매겨진 이벤트 생성
+func (a *AccountAggregate) newEvents(data ...EventData) ([]Event, error) {
+	events := make([]Event, 0, len(data))
+	for i, d := range data {
+		event, err := NewEvent(a.ID, d)
+		if err != nil {
+			return nil, err
+		}
+		event.Version = a.Version + int64(i) + 1
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountAggregate(t *testing.T) {
+	decideAndApply := func(t *testing.T, a *AccountAggregate, cmd interface{}) []Event {
+		events, err := a.Decide(cmd)
+		assert.NoError(t, err)
+		for _, e := range events {
+			assert.NoError(t, a.Apply(e))
+		}
+		return events
+	}
+
+	t.Run("Rehydrate", func(t *testing.T) {
+		a := NewAccountAggregate("acc-1")
+		var history []Event
+		history = append(history, decideAndApply(t, a, CreateAccountCommand{AccountId: "acc-1", UserName: "kim", InitialBalance: 500})...)
+		history = append(history, decideAndApply(t, a, DepositCommand{AccountID: "acc-1", Amount: 300})...)
+		history = append(history, decideAndApply(t, a, WithdrawCommand{AccountID: "acc-1", Amount: 200})...)
+
+		restored, err := RehydrateAccount("acc-1", history)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(600), restored.Balance)
+		assert.Equal(t, "kim", restored.UserName)
+		assert.Equal(t, int64(3), restored.Version)
+		assert.Equal(t, a.Balance, restored.Balance)
+	})
+
+	t.Run("Decide", func(t *testing.T) {
+		a := NewAccountAggregate("acc-2")
+
+		_, err := a.Decide(DepositCommand{AccountID: "acc-2", Amount: 100})
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+
+		decideAndApply(t, a, CreateAccountCommand{AccountId: "acc-2", InitialBalance: 100})
+
+		_, err = a.Decide(CreateAccountCommand{AccountId: "acc-2"})
+		assert.ErrorIs(t, err, ErrAccountAlreadyExists)
+
+		_, err = a.Decide(WithdrawCommand{AccountID: "acc-2", Amount: 101})
+		assert.ErrorIs(t, err, ErrInsufficientBalance)
+
+		_, err = a.Decide(DepositCommand{AccountID: "acc-2", Amount: 0})
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+
+		// Decide 는 상태를 바꾸지 않는다
+		assert.Equal(t, int64(100), a.Balance)
+		assert.Equal(t, int64(1), a.Version)
+	})
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AccountSummary 이벤트 스트림에서 만든 계좌 조회용 모델 (account_summaries 프로젝션)
+type AccountSummary struct {
+	AccountID        string    `gorm:"column:account_id;primaryKey"`
+	UserName         string    `gorm:"column:user_name"`
+	Balance          int64     `gorm:"column:balance"`
+	TotalDeposits    int64     `gorm:"column:total_deposits"`
+	TotalWithdrawals int64     `gorm:"column:total_withdrawals"`
+	TransactionCount int       `gorm:"column:transaction_count"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	LastActivityAt   time.Time `gorm:"column:last_activity_at"`
+	Version          int64     `gorm:"column:version"` // 마지막으로 반영한 이벤트의 계좌 스트림 버전
+}
+
+func (AccountSummary) TableName() string {
+	return "account_summaries"
+}
+
+// Apply 이벤트 하나를 요약에 반영
+// 프로젝션과 특정 시점 조회가 같은 규칙으로 상태를 만들도록 여기에 모아 둠
+func (s *AccountSummary) Apply(event Event) error {
+	data, err := event.DecodeData()
+	if err != nil {
+		return err
+	}
+
+	switch d := data.(type) {
+	case AccountCreatedData:
+		s.AccountID = event.AccountID
+		s.UserName = d.UserName
+		s.Balance = d.InitialBalance
+		s.CreatedAt = event.CreatedAt
+	case MoneyDepositedData:
+		s.Balance += d.Amount
+		s.TotalDeposits += d.Amount
+		s.TransactionCount++
+	case MoneyWithdrawnData:
+		s.Balance -= d.Amount
+		s.TotalWithdrawals += d.Amount
+		s.TransactionCount++
+	}
+	s.LastActivityAt = event.CreatedAt
+	s.Version = event.Version
+	return nil
+}
+
+// Response 조회 API 응답으로 변환
+func (s AccountSummary) Response() AccountResponse {
+	return AccountResponse{
+		ID:               s.AccountID,
+		Balance:          s.Balance,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.LastActivityAt,
+		UserName:         s.UserName,
+		TotalDeposits:    s.TotalDeposits,
+		TotalWithdrawals: s.TotalWithdrawals,
+		TransactionCount: s.TransactionCount,
+		Version:          s.Version,
+	}
+}
+
+// AccountSummaryStore 계좌 요약 조회 모델 저장소
+type AccountSummaryStore interface {
+	// FindByID 계좌 요약 조회, 없으면 ErrAccountNotFound
+	FindByID(ctx context.Context, accountID string) (*AccountSummary, error)
+	// ListAll 모든 계좌 요약을 생성 순서대로 조회
+	ListAll(ctx context.Context) ([]AccountSummary, error)
+	// Save 계좌 요약이 없으면 만들고 있으면 덮어씀
+	Save(ctx context.Context, summary *AccountSummary) error
+	// Delete 계좌 요약 삭제, 없는 계좌는 무시 (일부 계좌만 다시 만들 때 사용)
+	Delete(ctx context.Context, accountIDs []string) error
+	// Reset 모든 계좌 요약 삭제 (프로젝션을 처음부터 다시 만들 때 사용)
+	Reset(ctx context.Context) error
+}
+
+// AccountSummaryShadower 계좌 요약을 임시 테이블에 다시 만든 뒤 한 번에 교체할 수 있는 저장소
+// 재구성하는 동안에도 기존 테이블로 조회를 계속할 수 있음
+type AccountSummaryShadower interface {
+	// CreateShadow 비어 있는 임시 테이블을 새로 만들고 그 테이블에 쓰는 저장소를 반환
+	CreateShadow(ctx context.Context) (AccountSummaryStore, error)
+	// PromoteShadow 임시 테이블을 실제 테이블로 교체, 트랜잭션 안에서 호출해야 함
+	PromoteShadow(ctx context.Context) error
+}
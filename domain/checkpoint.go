@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ProjectionCheckpoint 프로젝션이 마지막으로 반영한 이벤트의 전체 로그 position
+type ProjectionCheckpoint struct {
+	ProjectionName string    `gorm:"column:projection_name;primaryKey"`
+	Position       int64     `gorm:"column:position"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (ProjectionCheckpoint) TableName() string {
+	return "projection_checkpoints"
+}
+
+// CheckpointStore 프로젝션별 체크포인트 저장소
+type CheckpointStore interface {
+	// Load 체크포인트 position 조회, 기록이 없으면 0
+	Load(ctx context.Context, projectionName string) (int64, error)
+	// Save 체크포인트 기록
+	// 프로젝션의 쓰기와 같은 트랜잭션 안에서 호출해야 함
+	Save(ctx context.Context, projectionName string, position int64) error
+	// List 모든 체크포인트 조회
+	List(ctx context.Context) ([]ProjectionCheckpoint, error)
+	// Delete 체크포인트 삭제, 기록이 없어도 에러 아님
+	Delete(ctx context.Context, projectionName string) error
+}
@@ -1,8 +1,54 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrInsufficientBalance = errors.New("insufficient balance")
-	ErrAccountNotFound     = errors.New("account not found")
+	ErrInsufficientBalance  = errors.New("insufficient balance")
+	ErrAccountNotFound      = errors.New("account not found")
+	ErrAccountAlreadyExists = errors.New("account already exists")
+	ErrInvalidAmount        = errors.New("invalid amount")
+	ErrConcurrencyConflict  = errors.New("concurrency conflict")
+	ErrUnknownEventType     = errors.New("unknown event type")
+	ErrMissingEventID       = errors.New("event id is required")
+	ErrDuplicateEventID     = errors.New("duplicate event id")
+	// ErrReadModelBehind 조회 모델이 요청한 position 까지 제한 시간 안에 반영하지 못함
+	ErrReadModelBehind = errors.New("read model has not caught up")
+	// ErrVersionNotFound 계좌 스트림에 요청한 버전이 없음
+	ErrVersionNotFound = errors.New("version not found")
+	// ErrUnsupportedSchemaVersion 현재 코드가 아는 것보다 높은 스키마 버전의 이벤트
+	ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
 )
+
+// EventError EventStore.Save 에서 특정 이벤트 때문에 실패한 경우, 어떤 이벤트인지 알려줌
+type EventError struct {
+	Index     int // Save 에 넘긴 events 안의 위치
+	EventID   string
+	EventType string
+	Err       error
+}
+
+func (e *EventError) Error() string {
+	return fmt.Sprintf("event #%d (%s %s): %v", e.Index, e.EventType, e.EventID, e.Err)
+}
+
+func (e *EventError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateNewEvents 저장 전에 ID 가 비었거나 배치 안에서 중복된 이벤트를 찾음
+func ValidateNewEvents(events []Event) error {
+	seen := make(map[string]bool, len(events))
+	for i, event := range events {
+		if event.ID == "" {
+			return &EventError{Index: i, EventType: event.EventType, Err: ErrMissingEventID}
+		}
+		if seen[event.ID] {
+			return &EventError{Index: i, EventID: event.ID, EventType: event.EventType, Err: ErrDuplicateEventID}
+		}
+		seen[event.ID] = true
+	}
+	return nil
+}
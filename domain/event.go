@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type EventType string
@@ -14,12 +16,31 @@ const (
 )
 
 type Event struct {
-	ID        string    `gorm:"column:id;primaryKey"`
-	AccountID string    `gorm:"column:account_id"`
-	EventType string    `gorm:"column:event_type"`
-	EventData []byte    `gorm:"column:event_data"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	Amount    int64     `gorm:"-"`
+	ID            string    `gorm:"column:id;primaryKey"`
+	AccountID     string    `gorm:"column:account_id"`
+	EventType     string    `gorm:"column:event_type"`
+	Version       int64     `gorm:"column:version"`                // 계좌 스트림 내 순번 (1부터 시작)
+	Position      int64     `gorm:"column:position;autoIncrement"` // 전체 이벤트 로그 내 순번, 저장소가 저장 시 발급 (1부터 증가)
+	EventData     []byte    `gorm:"column:event_data"`
+	SchemaVersion int       `gorm:"column:schema_version"` // EventData 페이로드 스키마 버전
+	CreatedAt     time.Time `gorm:"column:created_at"`
+}
+
+// NewEvent 타입 있는 페이로드로 새 이벤트 생성 (ID 는 항상 새로 발급)
+func NewEvent(accountID string, data EventData) (Event, error) {
+	byteData, err := DefaultEventRegistry.Encode(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		ID:            uuid.New().String(),
+		AccountID:     accountID,
+		EventType:     string(data.EventType()),
+		EventData:     byteData,
+		SchemaVersion: DefaultEventRegistry.SchemaVersion(data.EventType()),
+		CreatedAt:     time.Now(),
+	}, nil
 }
 
 func (e Event) TableName() string {
@@ -43,6 +64,11 @@ func (e Event) GetData() interface{} {
 	return e.EventData
 }
 
+// DecodeData EventData 를 이벤트 타입에 맞는 페이로드 구조체로 디코딩
+func (e Event) DecodeData() (EventData, error) {
+	return DefaultEventRegistry.Decode(e.EventType, e.EventData)
+}
+
 type EventPublisher interface {
 	Publish(ctx context.Context, event Event) error
 	PublishAll(ctx context.Context, events []Event) error
@@ -56,12 +82,32 @@ type EventHandler interface {
 	Handle(ctx context.Context, event Event) error
 }
 
+// EventHandlerFunc 함수를 EventHandler 로 사용하기 위한 어댑터
+type EventHandlerFunc func(ctx context.Context, event Event) error
+
+func (f EventHandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// WildcardEventType 모든 이벤트 타입을 구독할 때 사용하는 이벤트 타입
+const WildcardEventType = "*"
+
 type EventBus interface {
 	Subscribe(eventType string, handler EventHandler)
 	Publish(ctx context.Context, event Event) error
 }
 
 type EventStore interface {
-	Save(ctx context.Context, accountId string, events []Event) error
+	// Save 스트림의 현재 버전이 expectedVersion 과 다르면 ErrConcurrencyConflict 반환
+	Save(ctx context.Context, accountId string, expectedVersion int64, events []Event) error
 	Load(ctx context.Context, accountId string) ([]Event, error)
+	// LoadAfter afterVersion 보다 큰 버전의 이벤트만 조회 (스냅샷 이후 이벤트 재생용)
+	LoadAfter(ctx context.Context, accountId string, afterVersion int64) ([]Event, error)
+	// LoadRange 계좌 스트림에서 조건에 맞는 이벤트를 버전 순서대로 조회
+	LoadRange(ctx context.Context, accountId string, query EventQuery) ([]Event, error)
+	// Stream 계좌 스트림에서 조건에 맞는 이벤트를 버전 순서대로 나눠 읽는 반복자 (긴 히스토리용)
+	Stream(ctx context.Context, accountId string, query EventQuery) EventIterator
+	// ReadAll 모든 계좌의 이벤트 중 fromPosition 보다 큰 위치의 이벤트를 위치 순서대로 최대 limit 개 조회
+	// 마지막으로 받은 이벤트의 Position 을 다음 호출의 fromPosition 으로 넘기면 빠짐없이 이어서 읽을 수 있음
+	ReadAll(ctx context.Context, fromPosition int64, limit int) ([]Event, error)
 }
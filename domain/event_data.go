@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// EventData 이벤트 페이로드 타입이 구현하는 인터페이스
+type EventData interface {
+	EventType() EventType
+}
+
+// AccountCreatedData AccountCreated 이벤트 페이로드
+type AccountCreatedData struct {
+	AccountID      string `json:"account_id"`
+	UserName       string `json:"user_name"`
+	InitialBalance int64  `json:"initial_balance"`
+}
+
+func (AccountCreatedData) EventType() EventType { return AccountCreated }
+
+// MoneyDepositedData MoneyDeposited 이벤트 페이로드
+type MoneyDepositedData struct {
+	AccountID       string `json:"account_id"`
+	Amount          int64  `json:"amount"`
+	OriginalBalance int64  `json:"original_balance"`
+}
+
+func (MoneyDepositedData) EventType() EventType { return MoneyDeposited }
+
+// MoneyWithdrawnData MoneyWithdrawn 이벤트 페이로드
+type MoneyWithdrawnData struct {
+	AccountID       string `json:"account_id"`
+	Amount          int64  `json:"amount"`
+	OriginalBalance int64  `json:"original_balance"`
+}
+
+func (MoneyWithdrawnData) EventType() EventType { return MoneyWithdrawn }
+
+// EventRegistry EventType 별 페이로드 타입을 등록해두고 Event.EventData 를 인코딩/디코딩
+type EventRegistry struct {
+	mu        sync.RWMutex
+	factories map[EventType]func() EventData
+	upcasters map[EventType]map[int]Upcaster
+}
+
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		factories: make(map[EventType]func() EventData),
+		upcasters: make(map[EventType]map[int]Upcaster),
+	}
+}
+
+// DefaultEventRegistry 계좌 이벤트들이 등록된 기본 레지스트리
+var DefaultEventRegistry = NewEventRegistry()
+
+func init() {
+	DefaultEventRegistry.Register(AccountCreated, func() EventData { return &AccountCreatedData{} })
+	DefaultEventRegistry.Register(MoneyDeposited, func() EventData { return &MoneyDepositedData{} })
+	DefaultEventRegistry.Register(MoneyWithdrawn, func() EventData { return &MoneyWithdrawnData{} })
+}
+
+// Register 이벤트 타입에 대한 페이로드 생성 함수 등록 (factory 는 포인터를 반환해야 함)
+func (r *EventRegistry) Register(eventType EventType, factory func() EventData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[eventType] = factory
+}
+
+// Encode 등록된 페이로드를 JSON 으로 직렬화
+func (r *EventRegistry) Encode(data EventData) ([]byte, error) {
+	r.mu.RLock()
+	_, ok := r.factories[data.EventType()]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, data.EventType())
+	}
+
+	byteData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event data: %w", data.EventType(), err)
+	}
+	return byteData, nil
+}
+
+// Decode 이벤트 타입에 맞는 페이로드 구조체로 역직렬화 (값 타입으로 반환)
+// raw 는 현재 스키마 버전이어야 하므로 저장소/메시지에서 읽은 이벤트는 먼저 Upcast 를 거쳐야 함
+func (r *EventRegistry) Decode(eventType string, raw []byte) (EventData, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[EventType(eventType)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+
+	data := factory()
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event data: %w", eventType, err)
+	}
+	return reflect.ValueOf(data).Elem().Interface().(EventData), nil
+}
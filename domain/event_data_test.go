@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventRegistry(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		event, err := NewEvent("acc-1", MoneyDepositedData{AccountID: "acc-1", Amount: 700, OriginalBalance: 100})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, event.ID)
+		assert.Equal(t, string(MoneyDeposited), event.EventType)
+
+		data, err := event.DecodeData()
+		assert.NoError(t, err)
+		assert.Equal(t, MoneyDepositedData{AccountID: "acc-1", Amount: 700, OriginalBalance: 100}, data)
+	})
+
+	t.Run("UnknownEventType", func(t *testing.T) {
+		_, err := DefaultEventRegistry.Decode("AccountClosed", []byte(`{}`))
+		assert.ErrorIs(t, err, ErrUnknownEventType)
+
+		_, err = NewEventRegistry().Encode(AccountCreatedData{})
+		assert.ErrorIs(t, err, ErrUnknownEventType)
+	})
+}
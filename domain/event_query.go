@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StreamPageSize 스트리밍 조회 시 한 번에 읽어오는 이벤트 수
+const StreamPageSize = 500
+
+// EventQuery 계좌 스트림의 범위 조회 조건, 0 값인 필드는 조건에서 빠짐
+type EventQuery struct {
+	FromVersion int64       // 이 버전부터 (포함)
+	ToVersion   int64       // 이 버전까지 (포함)
+	FromTime    time.Time   // 이 시각부터 (포함)
+	ToTime      time.Time   // 이 시각 전까지 (제외)
+	EventTypes  []EventType // 이 타입들만
+	Limit       int         // 최대 개수
+}
+
+// EventTypeNames EventTypes 를 저장소 조회용 문자열로 변환
+func (q EventQuery) EventTypeNames() []string {
+	names := make([]string, len(q.EventTypes))
+	for i, t := range q.EventTypes {
+		names[i] = string(t)
+	}
+	return names
+}
+
+// Matches 이벤트가 버전, 시각, 타입 조건을 만족하는지 확인 (Limit 은 보지 않음)
+func (q EventQuery) Matches(event Event) bool {
+	if q.FromVersion > 0 && event.Version < q.FromVersion {
+		return false
+	}
+	if q.ToVersion > 0 && event.Version > q.ToVersion {
+		return false
+	}
+	if !q.FromTime.IsZero() && event.CreatedAt.Before(q.FromTime) {
+		return false
+	}
+	if !q.ToTime.IsZero() && !event.CreatedAt.Before(q.ToTime) {
+		return false
+	}
+	if len(q.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range q.EventTypes {
+		if string(t) == event.EventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventIterator 이벤트를 한 건씩 읽는 반복자 (database/sql 의 Rows 와 같은 방식)
+//
+//	it := store.Stream(ctx, accountID, domain.EventQuery{})
+//	defer it.Close()
+//	for it.Next() {
+//		event := it.Event()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type EventIterator interface {
+	// Next 다음 이벤트로 이동, 더 없거나 에러가 나면 false
+	Next() bool
+	// Event 현재 이벤트
+	Event() Event
+	// Err 반복 중 발생한 에러
+	Err() error
+	// Close 반복 종료
+	Close() error
+}
+
+// RangeLoader 계좌 스트림의 범위 조회 함수 (EventStore.LoadRange)
+type RangeLoader func(ctx context.Context, accountId string, query EventQuery) ([]Event, error)
+
+// NewPagedEventIterator 버전 순서대로 StreamPageSize 개씩 나눠 읽는 반복자
+// 연결을 붙잡고 있지 않아서 반복 중에도 같은 저장소를 사용할 수 있음
+func NewPagedEventIterator(ctx context.Context, load RangeLoader, accountId string, query EventQuery) EventIterator {
+	return &pagedEventIterator{
+		ctx:       ctx,
+		load:      load,
+		accountId: accountId,
+		query:     query,
+		remaining: query.Limit,
+		index:     -1,
+	}
+}
+
+type pagedEventIterator struct {
+	ctx       context.Context
+	load      RangeLoader
+	accountId string
+	query     EventQuery
+	remaining int // 남은 개수 (Limit 이 없으면 0 으로 유지)
+	page      []Event
+	index     int
+	last      bool // 마지막 페이지를 읽었음
+	err       error
+	closed    bool
+}
+
+func (it *pagedEventIterator) Next() bool {
+	if it.closed || it.err != nil {
+		return false
+	}
+	if it.index+1 < len(it.page) {
+		it.index++
+		return true
+	}
+	if it.last {
+		return false
+	}
+
+	pageSize := StreamPageSize
+	if it.query.Limit > 0 {
+		if it.remaining <= 0 {
+			return false
+		}
+		pageSize = min(pageSize, it.remaining)
+	}
+
+	query := it.query
+	query.Limit = pageSize
+	if len(it.page) > 0 {
+		query.FromVersion = it.page[len(it.page)-1].Version + 1
+	}
+
+	page, err := it.load(it.ctx, it.accountId, query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if it.query.Limit > 0 {
+		it.remaining -= len(page)
+	}
+	it.last = len(page) < pageSize
+	it.page, it.index = page, 0
+	return len(page) > 0
+}
+
+func (it *pagedEventIterator) Event() Event {
+	if it.index < 0 || it.index >= len(it.page) {
+		return Event{}
+	}
+	return it.page[it.index]
+}
+
+func (it *pagedEventIterator) Err() error {
+	return it.err
+}
+
+func (it *pagedEventIterator) Close() error {
+	it.closed = true
+	it.page = nil
+	return nil
+}
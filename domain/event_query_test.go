@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagedEventIterator(t *testing.T) {
+	ctx := context.Background()
+
+	// 버전 1 부터 n 까지의 스트림을 조건대로 잘라주는 로더, 호출된 쿼리를 기록
+	newLoader := func(n int64, queries *[]EventQuery) RangeLoader {
+		return func(ctx context.Context, accountId string, query EventQuery) ([]Event, error) {
+			*queries = append(*queries, query)
+			var events []Event
+			for v := int64(1); v <= n; v++ {
+				event := Event{AccountID: accountId, Version: v, EventType: string(MoneyDeposited)}
+				if !query.Matches(event) {
+					continue
+				}
+				if query.Limit > 0 && len(events) >= query.Limit {
+					break
+				}
+				events = append(events, event)
+			}
+			return events, nil
+		}
+	}
+	collect := func(it EventIterator) []int64 {
+		defer it.Close()
+		var versions []int64
+		for it.Next() {
+			versions = append(versions, it.Event().Version)
+		}
+		assert.NoError(t, it.Err())
+		return versions
+	}
+
+	t.Run("페이지 단위로 이어서 읽음", func(t *testing.T) {
+		var queries []EventQuery
+		total := int64(StreamPageSize*2 + 10)
+		versions := collect(NewPagedEventIterator(ctx, newLoader(total, &queries), "acc-1", EventQuery{}))
+
+		assert.Len(t, versions, int(total))
+		assert.Equal(t, total, versions[len(versions)-1])
+		assert.Len(t, queries, 3)
+		assert.Equal(t, int64(StreamPageSize+1), queries[1].FromVersion)
+		for _, q := range queries {
+			assert.Equal(t, StreamPageSize, q.Limit)
+		}
+	})
+
+	t.Run("전체 개수 제한", func(t *testing.T) {
+		var queries []EventQuery
+		versions := collect(NewPagedEventIterator(ctx, newLoader(StreamPageSize*3, &queries), "acc-1",
+			EventQuery{FromVersion: 5, Limit: StreamPageSize + 1}))
+
+		assert.Len(t, versions, StreamPageSize+1)
+		assert.Equal(t, int64(5), versions[0])
+		assert.Len(t, queries, 2)
+		assert.Equal(t, 1, queries[1].Limit)
+	})
+
+	t.Run("로더 에러", func(t *testing.T) {
+		cause := errors.New("connection lost")
+		it := NewPagedEventIterator(ctx, func(ctx context.Context, accountId string, query EventQuery) ([]Event, error) {
+			return nil, cause
+		}, "acc-1", EventQuery{})
+
+		assert.False(t, it.Next())
+		assert.ErrorIs(t, it.Err(), cause)
+	})
+}
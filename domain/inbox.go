@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ProcessedEvent 컨슈머 측 핸들러가 처리 완료한 이벤트 기록
+type ProcessedEvent struct {
+	HandlerName string    `gorm:"column:handler_name;primaryKey"`
+	EventID     string    `gorm:"column:event_id;primaryKey"`
+	ProcessedAt time.Time `gorm:"column:processed_at"`
+}
+
+func (ProcessedEvent) TableName() string {
+	return "inbox"
+}
+
+// Inbox 핸들러별 처리한 이벤트 ID 를 기록해서 재전달된 이벤트를 걸러내는 저장소
+type Inbox interface {
+	// Record 처리 기록을 남기고, 이미 기록된 이벤트면 false 반환
+	// 핸들러의 쓰기와 같은 트랜잭션 안에서 호출해야 함
+	Record(ctx context.Context, handlerName string, eventID string) (bool, error)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	domain "go-eventsourcing-patterns/domain"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,11 +37,12 @@ func (m *MockAccountCommandService) EXPECT() *MockAccountCommandServiceMockRecor
 }
 
 // CreateAccount mocks base method.
-func (m *MockAccountCommandService) CreateAccount(ctx context.Context, cmd domain.CreateAccountCommand) error {
+func (m *MockAccountCommandService) CreateAccount(ctx context.Context, cmd domain.CreateAccountCommand) (domain.CommandResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateAccount", ctx, cmd)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(domain.CommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CreateAccount indicates an expected call of CreateAccount.
@@ -50,11 +52,12 @@ func (mr *MockAccountCommandServiceMockRecorder) CreateAccount(ctx, cmd interfac
 }
 
 // Deposit mocks base method.
-func (m *MockAccountCommandService) Deposit(ctx context.Context, cmd domain.DepositCommand) error {
+func (m *MockAccountCommandService) Deposit(ctx context.Context, cmd domain.DepositCommand) (domain.CommandResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Deposit", ctx, cmd)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(domain.CommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Deposit indicates an expected call of Deposit.
@@ -64,11 +67,12 @@ func (mr *MockAccountCommandServiceMockRecorder) Deposit(ctx, cmd interface{}) *
 }
 
 // Withdraw mocks base method.
-func (m *MockAccountCommandService) Withdraw(ctx context.Context, cmd domain.WithdrawCommand) error {
+func (m *MockAccountCommandService) Withdraw(ctx context.Context, cmd domain.WithdrawCommand) (domain.CommandResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Withdraw", ctx, cmd)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(domain.CommandResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Withdraw indicates an expected call of Withdraw.
@@ -100,49 +104,79 @@ func (m *MockAccountQueryService) EXPECT() *MockAccountQueryServiceMockRecorder
 	return m.recorder
 }
 
+// GetAccountAsOf mocks base method.
+func (m *MockAccountQueryService) GetAccountAsOf(ctx context.Context, accountID string, asOf time.Time) (*domain.AccountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountAsOf", ctx, accountID, asOf)
+	ret0, _ := ret[0].(*domain.AccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountAsOf indicates an expected call of GetAccountAsOf.
+func (mr *MockAccountQueryServiceMockRecorder) GetAccountAsOf(ctx, accountID, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountAsOf", reflect.TypeOf((*MockAccountQueryService)(nil).GetAccountAsOf), ctx, accountID, asOf)
+}
+
+// GetAccountAtVersion mocks base method.
+func (m *MockAccountQueryService) GetAccountAtVersion(ctx context.Context, accountID string, version int64) (*domain.AccountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountAtVersion", ctx, accountID, version)
+	ret0, _ := ret[0].(*domain.AccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountAtVersion indicates an expected call of GetAccountAtVersion.
+func (mr *MockAccountQueryServiceMockRecorder) GetAccountAtVersion(ctx, accountID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountAtVersion", reflect.TypeOf((*MockAccountQueryService)(nil).GetAccountAtVersion), ctx, accountID, version)
+}
+
 // GetAccountByID mocks base method.
-func (m *MockAccountQueryService) GetAccountByID(ctx context.Context, accountID string) (*domain.AccountResponse, error) {
+func (m *MockAccountQueryService) GetAccountByID(ctx context.Context, accountID string, minPosition int64) (*domain.AccountResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountByID", ctx, accountID)
+	ret := m.ctrl.Call(m, "GetAccountByID", ctx, accountID, minPosition)
 	ret0, _ := ret[0].(*domain.AccountResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountByID indicates an expected call of GetAccountByID.
-func (mr *MockAccountQueryServiceMockRecorder) GetAccountByID(ctx, accountID interface{}) *gomock.Call {
+func (mr *MockAccountQueryServiceMockRecorder) GetAccountByID(ctx, accountID, minPosition interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockAccountQueryService)(nil).GetAccountByID), ctx, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockAccountQueryService)(nil).GetAccountByID), ctx, accountID, minPosition)
 }
 
 // GetAccountHistory mocks base method.
-func (m *MockAccountQueryService) GetAccountHistory(ctx context.Context, accountID string) ([]domain.Event, error) {
+func (m *MockAccountQueryService) GetAccountHistory(ctx context.Context, accountID string, query domain.EventQuery) ([]domain.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountHistory", ctx, accountID)
+	ret := m.ctrl.Call(m, "GetAccountHistory", ctx, accountID, query)
 	ret0, _ := ret[0].([]domain.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountHistory indicates an expected call of GetAccountHistory.
-func (mr *MockAccountQueryServiceMockRecorder) GetAccountHistory(ctx, accountID interface{}) *gomock.Call {
+func (mr *MockAccountQueryServiceMockRecorder) GetAccountHistory(ctx, accountID, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHistory", reflect.TypeOf((*MockAccountQueryService)(nil).GetAccountHistory), ctx, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHistory", reflect.TypeOf((*MockAccountQueryService)(nil).GetAccountHistory), ctx, accountID, query)
 }
 
 // ListAccounts mocks base method.
-func (m *MockAccountQueryService) ListAccounts(ctx context.Context) ([]domain.AccountResponse, error) {
+func (m *MockAccountQueryService) ListAccounts(ctx context.Context, minPosition int64) ([]domain.AccountResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", ctx)
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, minPosition)
 	ret0, _ := ret[0].([]domain.AccountResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockAccountQueryServiceMockRecorder) ListAccounts(ctx interface{}) *gomock.Call {
+func (mr *MockAccountQueryServiceMockRecorder) ListAccounts(ctx, minPosition interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountQueryService)(nil).ListAccounts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountQueryService)(nil).ListAccounts), ctx, minPosition)
 }
 
 // MockAccountStore is a mock of AccountStore interface.
@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+// Notifier 계좌 활동을 계좌 주인에게 알리는 외부 채널 (메일, 푸시 등)
+type Notifier interface {
+	Notify(ctx context.Context, accountID string, message string) error
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxMessage 커맨드 트랜잭션 안에서 기록되고 릴레이가 나중에 발행하는 메시지
+type OutboxMessage struct {
+	ID        int64      `gorm:"column:id;primaryKey;autoIncrement"`
+	EventID   string     `gorm:"column:event_id"`
+	AccountID string     `gorm:"column:account_id"`
+	Payload   []byte     `gorm:"column:payload"` // JSON 으로 직렬화된 Event
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	SentAt    *time.Time `gorm:"column:sent_at"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
+
+// Outbox 트랜잭셔널 아웃박스 저장소 인터페이스
+type Outbox interface {
+	// Add 컨텍스트의 트랜잭션 안에서 발행할 이벤트들을 기록
+	Add(ctx context.Context, events []Event) error
+	// FetchPending 아직 발행되지 않은 메시지를 기록된 순서대로 조회
+	// 트랜잭션 안에서 호출하면 커밋될 때까지 다른 릴레이가 같은 메시지를 가져가지 못하고,
+	// 다른 릴레이가 잡고 있는 메시지와 그 뒤의 같은 계좌 메시지는 기다리지 않고 건너뜀
+	FetchPending(ctx context.Context, limit int) ([]OutboxMessage, error)
+	// MarkSent 발행 완료된 메시지 표시
+	MarkSent(ctx context.Context, ids []int64) error
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Snapshot 특정 버전 시점의 애그리거트 상태 (계좌별 최신 스냅샷 하나만 유지)
+type Snapshot struct {
+	AccountID string    `gorm:"column:account_id;primaryKey"`
+	Version   int64     `gorm:"column:version"`
+	State     []byte    `gorm:"column:state"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (Snapshot) TableName() string {
+	return "snapshots"
+}
+
+// SnapshotStore 스냅샷 저장소 인터페이스
+type SnapshotStore interface {
+	// Save 계좌의 스냅샷을 저장 (기존 스냅샷은 교체)
+	Save(ctx context.Context, snapshot Snapshot) error
+	// Load 계좌의 최신 스냅샷 조회, 없으면 nil 반환
+	Load(ctx context.Context, accountID string) (*Snapshot, error)
+}
+
+// SnapshotPolicy 커맨드 처리 후 스냅샷을 남길지 결정
+type SnapshotPolicy interface {
+	ShouldSnapshot(aggregate *AccountAggregate, newEvents int) bool
+}
+
+// SnapshotEvery N 개 이벤트마다 스냅샷을 남기는 정책 (0 이하이면 자동 스냅샷 없음 = 요청 시에만)
+type SnapshotEvery int64
+
+func (n SnapshotEvery) ShouldSnapshot(aggregate *AccountAggregate, newEvents int) bool {
+	if n <= 0 {
+		return false
+	}
+	previous := aggregate.Version - int64(newEvents)
+	return aggregate.Version/int64(n) > previous/int64(n)
+}
+
+// Snapshot 현재 상태를 스냅샷으로 직렬화
+func (a *AccountAggregate) Snapshot() (Snapshot, error) {
+	state, err := json.Marshal(a)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return Snapshot{
+		AccountID: a.ID,
+		Version:   a.Version,
+		State:     state,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// RestoreAccount 스냅샷에서 시작해서 그 이후 이벤트들만 적용해 애그리거트 복원
+func RestoreAccount(snapshot Snapshot, events []Event) (*AccountAggregate, error) {
+	aggregate := &AccountAggregate{}
+	if err := json.Unmarshal(snapshot.State, aggregate); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	aggregate.Version = snapshot.Version
+
+	for _, event := range events {
+		if err := aggregate.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return aggregate, nil
+}
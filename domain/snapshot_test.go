@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("SnapshotEvery", func(t *testing.T) {
+		policy := SnapshotEvery(3)
+		assert.False(t, policy.ShouldSnapshot(&AccountAggregate{Version: 2}, 1))
+		assert.True(t, policy.ShouldSnapshot(&AccountAggregate{Version: 3}, 1))
+		assert.True(t, policy.ShouldSnapshot(&AccountAggregate{Version: 7}, 3))
+		assert.False(t, SnapshotEvery(0).ShouldSnapshot(&AccountAggregate{Version: 3}, 1))
+	})
+
+	t.Run("RestoreAccount", func(t *testing.T) {
+		a := NewAccountAggregate("acc-1")
+		for _, cmd := range []interface{}{
+			CreateAccountCommand{AccountId: "acc-1", UserName: "lee", InitialBalance: 100},
+			DepositCommand{AccountID: "acc-1", Amount: 50},
+			WithdrawCommand{AccountID: "acc-1", Amount: 30},
+		} {
+			events, err := a.Decide(cmd)
+			assert.NoError(t, err)
+			assert.NoError(t, a.Apply(events[0]))
+		}
+
+		snapshot, err := a.Snapshot()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), snapshot.Version)
+
+		events, err := a.Decide(DepositCommand{AccountID: "acc-1", Amount: 80})
+		assert.NoError(t, err)
+		assert.NoError(t, a.Apply(events[0]))
+
+		restored, err := RestoreAccount(snapshot, events)
+		assert.NoError(t, err)
+		assert.Equal(t, a.Balance, restored.Balance)
+		assert.Equal(t, a.Version, restored.Version)
+		assert.Equal(t, a.UserName, restored.UserName)
+		assert.True(t, a.CreatedAt.Equal(restored.CreatedAt))
+	})
+}
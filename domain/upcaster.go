@@ -0,0 +1,76 @@
+package domain
+
+import "fmt"
+
+// Upcaster 한 스키마 버전의 페이로드를 바로 다음 버전으로 변환
+type Upcaster func(data []byte) ([]byte, error)
+
+// RegisterUpcaster fromVersion -> fromVersion+1 변환 등록
+// 이벤트 타입의 현재 스키마 버전은 1 에서부터 연속으로 등록된 업캐스터 수만큼 올라감
+func (r *EventRegistry) RegisterUpcaster(eventType EventType, fromVersion int, upcaster Upcaster) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.upcasters[eventType] == nil {
+		r.upcasters[eventType] = make(map[int]Upcaster)
+	}
+	r.upcasters[eventType][fromVersion] = upcaster
+}
+
+// SchemaVersion 이벤트 타입의 현재 페이로드 스키마 버전
+func (r *EventRegistry) SchemaVersion(eventType EventType) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.schemaVersion(eventType)
+}
+
+func (r *EventRegistry) schemaVersion(eventType EventType) int {
+	version := 1
+	for r.upcasters[eventType][version] != nil {
+		version++
+	}
+	return version
+}
+
+// Upcast 이전 스키마 버전으로 저장된 이벤트를 업캐스터 체인을 거쳐 현재 버전으로 변환
+// 스키마 버전이 없는(0) 이벤트는 버전 1 로 간주
+func (r *EventRegistry) Upcast(event Event) (Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	eventType := EventType(event.EventType)
+	current := r.schemaVersion(eventType)
+
+	version := event.SchemaVersion
+	if version == 0 {
+		version = 1
+	}
+	if version > current {
+		return Event{}, fmt.Errorf("%w: %s v%d (current v%d)",
+			ErrUnsupportedSchemaVersion, event.EventType, version, current)
+	}
+
+	data := event.EventData
+	for ; version < current; version++ {
+		upcasted, err := r.upcasters[eventType][version](data)
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to upcast %s v%d: %w", event.EventType, version, err)
+		}
+		data = upcasted
+	}
+
+	event.EventData = data
+	event.SchemaVersion = current
+	return event, nil
+}
+
+// UpcastEvents 이벤트 목록 전체를 현재 스키마 버전으로 변환
+func UpcastEvents(events []Event) ([]Event, error) {
+	for i := range events {
+		upcasted, err := DefaultEventRegistry.Upcast(events[i])
+		if err != nil {
+			return nil, err
+		}
+		events[i] = upcasted
+	}
+	return events, nil
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpcast(t *testing.T) {
+	registry := NewEventRegistry()
+	registry.Register(MoneyDeposited, func() EventData { return &MoneyDepositedData{} })
+
+	// v1: {"value": n} -> v2: {"amount": n} -> v3: account_id 추가
+	registry.RegisterUpcaster(MoneyDeposited, 1, func(data []byte) ([]byte, error) {
+		var v1 struct {
+			Value int64 `json:"value"`
+		}
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]interface{}{"amount": v1.Value})
+	})
+	registry.RegisterUpcaster(MoneyDeposited, 2, func(data []byte) ([]byte, error) {
+		var v2 map[string]interface{}
+		if err := json.Unmarshal(data, &v2); err != nil {
+			return nil, err
+		}
+		v2["account_id"] = "legacy"
+		return json.Marshal(v2)
+	})
+	assert.Equal(t, 3, registry.SchemaVersion(MoneyDeposited))
+	assert.Equal(t, 1, registry.SchemaVersion(MoneyWithdrawn))
+
+	t.Run("OldVersion", func(t *testing.T) {
+		event, err := registry.Upcast(Event{EventType: string(MoneyDeposited), EventData: []byte(`{"value": 50}`)})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, event.SchemaVersion)
+
+		data, err := registry.Decode(event.EventType, event.EventData)
+		assert.NoError(t, err)
+		assert.Equal(t, MoneyDepositedData{AccountID: "legacy", Amount: 50}, data)
+	})
+
+	t.Run("CurrentVersion", func(t *testing.T) {
+		raw := []byte(`{"account_id":"acc-1","amount":10}`)
+		event, err := registry.Upcast(Event{EventType: string(MoneyDeposited), EventData: raw, SchemaVersion: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, raw, event.EventData)
+	})
+
+	t.Run("NewerVersion", func(t *testing.T) {
+		_, err := registry.Upcast(Event{EventType: string(MoneyDeposited), SchemaVersion: 4})
+		assert.ErrorIs(t, err, ErrUnsupportedSchemaVersion)
+	})
+}
@@ -0,0 +1,243 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/eventhandler"
+	"hash/fnv"
+	"log"
+	"sync"
+)
+
+// ErrBusClosed Close 이후에 Publish 를 호출한 경우
+var ErrBusClosed = errors.New("event bus closed")
+
+// ErrorHandler 비동기 모드에서 구독자 실행이 실패했을 때 호출
+type ErrorHandler func(subscriber string, event domain.Event, err error)
+
+// AsyncConfig 비동기 디스패치 설정
+type AsyncConfig struct {
+	Workers    int // 워커 수, 같은 계좌의 이벤트는 항상 같은 워커에서 순서대로 처리
+	BufferSize int // 워커별 대기 이벤트 수, 가득 차면 Publish 가 대기
+}
+
+// DefaultAsyncConfig 기본 비동기 디스패치 설정
+var DefaultAsyncConfig = AsyncConfig{
+	Workers:    4,
+	BufferSize: 100,
+}
+
+// Option NewInMemoryEventBus 설정 옵션
+type Option func(*InMemoryEventBus)
+
+// WithAsync Publish 가 구독자 실행을 기다리지 않고 워커에 넘기도록 설정
+func WithAsync(cfg AsyncConfig) Option {
+	return func(b *InMemoryEventBus) {
+		b.async = &cfg
+	}
+}
+
+// WithMiddleware 모든 구독자 실행을 감쌀 미들웨어 설정 (먼저 넣은 것이 바깥쪽)
+func WithMiddleware(middlewares ...eventhandler.Middleware) Option {
+	return func(b *InMemoryEventBus) {
+		b.middlewares = append(b.middlewares, middlewares...)
+	}
+}
+
+// WithErrorHandler 비동기 모드에서 실패한 구독자를 알릴 함수 설정 (기본은 로그)
+func WithErrorHandler(fn ErrorHandler) Option {
+	return func(b *InMemoryEventBus) {
+		b.onError = fn
+	}
+}
+
+// subscription 이벤트 타입에 등록된 이름 있는 구독자
+type subscription struct {
+	name    string
+	handler domain.EventHandler
+}
+
+// envelope 워커 큐에 넣는 이벤트와 발행 시점의 컨텍스트
+// 취소와 발행자의 트랜잭션은 전파하지 않고 트레이스 정보만 유지 (워커가 실행될 때는 이미 끝난 트랜잭션)
+type envelope struct {
+	ctx   context.Context
+	event domain.Event
+}
+
+// InMemoryEventBus 프로세스 안에서 이벤트를 구독자에게 전달하는 domain.EventBus 구현
+// 동기 모드는 Publish 안에서 구독자를 순서대로 실행하고, 비동기 모드는 계좌 ID 해시로 워커를 골라 계좌별 순서를 지킴
+// 한 구독자가 실패하거나 panic 이 나도 나머지 구독자는 실행됨
+type InMemoryEventBus struct {
+	mu            sync.RWMutex
+	subscriptions map[string][]subscription
+	middlewares   []eventhandler.Middleware
+	async         *AsyncConfig
+	onError       ErrorHandler
+	queues        []chan envelope
+	wg            sync.WaitGroup
+	publishers    sync.WaitGroup // 큐에 넣는 중인 Publish, Close 는 이들이 끝난 뒤에 큐를 닫음
+	done          chan struct{}  // Close 가 닫아서 큐가 가득 차 기다리는 Publish 를 깨움
+	closed        bool
+}
+
+func NewInMemoryEventBus(opts ...Option) *InMemoryEventBus {
+	b := &InMemoryEventBus{
+		subscriptions: make(map[string][]subscription),
+		done:          make(chan struct{}),
+		onError: func(subscriber string, event domain.Event, err error) {
+			log.Printf("Event subscriber failed: Subscriber=%s, Type=%s, EventID=%s, Error=%v",
+				subscriber, event.EventType, event.ID, err)
+		},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.async != nil {
+		if b.async.Workers < 1 {
+			b.async.Workers = 1
+		}
+		if b.async.BufferSize < 1 {
+			b.async.BufferSize = 1
+		}
+		b.queues = make([]chan envelope, b.async.Workers)
+		for i := range b.queues {
+			b.queues[i] = make(chan envelope, b.async.BufferSize)
+			b.wg.Add(1)
+			go b.work(b.queues[i])
+		}
+	}
+	return b
+}
+
+// Subscribe 이벤트 타입에 구독자 등록, 이름은 등록 순서로 만들어짐
+func (b *InMemoryEventBus) Subscribe(eventType string, handler domain.EventHandler) {
+	b.SubscribeNamed(eventType, "", handler)
+}
+
+// SubscribeNamed 이름 있는 구독자 등록 (로그, 트레이스, 메트릭에 이름이 사용됨)
+// domain.WildcardEventType 으로 등록하면 모든 이벤트를 받음
+func (b *InMemoryEventBus) SubscribeNamed(eventType string, name string, handler domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if name == "" {
+		name = fmt.Sprintf("%s#%d", eventType, len(b.subscriptions[eventType])+1)
+	}
+	middlewares := append(append([]eventhandler.Middleware{}, b.middlewares...), eventhandler.Recovery())
+	b.subscriptions[eventType] = append(b.subscriptions[eventType], subscription{
+		name:    name,
+		handler: eventhandler.Chain(name, handler, middlewares...),
+	})
+}
+
+// Publish 동기 모드에서는 모든 구독자를 실행하고 실패한 구독자의 에러를 모아서 반환
+// 비동기 모드에서는 워커 큐에 넣고 바로 반환, 큐가 가득 찬 동안 Close 되면 ErrBusClosed
+func (b *InMemoryEventBus) Publish(ctx context.Context, event domain.Event) error {
+	if b.async == nil {
+		b.mu.RLock()
+		closed := b.closed
+		b.mu.RUnlock()
+		if closed {
+			return ErrBusClosed
+		}
+		return b.dispatch(ctx, event)
+	}
+
+	// 큐가 가득 차면 여기서 대기하므로 잠금은 등록할 때만 잡음
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBusClosed
+	}
+	b.publishers.Add(1)
+	b.mu.RUnlock()
+	defer b.publishers.Done()
+
+	select {
+	case b.queues[b.workerFor(event)] <- envelope{ctx: detach(ctx), event: event}:
+		return nil
+	case <-b.done:
+		return ErrBusClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PublishAll 이벤트들을 순서대로 발행 (domain.EventPublisher 구현)
+func (b *InMemoryEventBus) PublishAll(ctx context.Context, events []domain.Event) error {
+	for _, event := range events {
+		if err := b.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 더 이상 이벤트를 받지 않고, 비동기 모드면 대기 중인 이벤트를 모두 처리할 때까지 기다림
+func (b *InMemoryEventBus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.done)
+	b.mu.Unlock()
+
+	b.publishers.Wait()
+	for _, q := range b.queues {
+		close(q)
+	}
+	b.wg.Wait()
+	return nil
+}
+
+// subscribersFor 이벤트 타입 구독자와 와일드카드 구독자 (실행 중에 구독이 바뀌어도 영향이 없도록 복사)
+func (b *InMemoryEventBus) subscribersFor(eventType string) []subscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	subs := append([]subscription{}, b.subscriptions[eventType]...)
+	if eventType != domain.WildcardEventType {
+		subs = append(subs, b.subscriptions[domain.WildcardEventType]...)
+	}
+	return subs
+}
+
+// dispatch 구독자를 순서대로 실행, 실패해도 다음 구독자는 실행
+func (b *InMemoryEventBus) dispatch(ctx context.Context, event domain.Event) error {
+	var errs []error
+	for _, sub := range b.subscribersFor(event.EventType) {
+		if err := sub.handler.Handle(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("subscriber %s failed: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// work 워커 큐의 이벤트를 순서대로 처리하고 실패한 구독자는 ErrorHandler 로 알림
+func (b *InMemoryEventBus) work(queue chan envelope) {
+	defer b.wg.Done()
+	for e := range queue {
+		for _, sub := range b.subscribersFor(e.event.EventType) {
+			if err := sub.handler.Handle(e.ctx, e.event); err != nil {
+				b.onError(sub.name, e.event, err)
+			}
+		}
+	}
+}
+
+// workerFor 계좌 ID 해시로 워커 선택
+func (b *InMemoryEventBus) workerFor(event domain.Event) int {
+	h := fnv.New32a()
+	h.Write([]byte(event.AccountID))
+	return int(h.Sum32() % uint32(len(b.queues)))
+}
+
+// detach 워커에 넘길 컨텍스트, 취소와 트랜잭션을 떼어냄
+func detach(ctx context.Context) context.Context {
+	return context.WithValue(context.WithoutCancel(ctx), domain.TxKey, nil)
+}
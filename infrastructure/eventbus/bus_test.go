@@ -0,0 +1,174 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryEventBus(t *testing.T) {
+	ctx := context.Background()
+	event := func(accountID string, version int64) domain.Event {
+		return domain.Event{
+			ID:        fmt.Sprintf("%s-%d", accountID, version),
+			AccountID: accountID,
+			EventType: string(domain.MoneyDeposited),
+			Version:   version,
+		}
+	}
+
+	t.Run("동기 모드는 실패한 구독자와 상관없이 모든 구독자를 실행", func(t *testing.T) {
+		bus := NewInMemoryEventBus()
+		defer bus.Close()
+
+		var calls []string
+		cause := errors.New("audit failed")
+		bus.SubscribeNamed(string(domain.MoneyDeposited), "audit",
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				calls = append(calls, "audit")
+				return cause
+			}))
+		bus.SubscribeNamed(string(domain.MoneyDeposited), "panicky",
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				calls = append(calls, "panicky")
+				panic("boom")
+			}))
+		bus.Subscribe(domain.WildcardEventType,
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				calls = append(calls, "all")
+				return nil
+			}))
+		bus.Subscribe(string(domain.MoneyWithdrawn),
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				calls = append(calls, "withdrawn")
+				return nil
+			}))
+
+		err := bus.Publish(ctx, event("account-1", 1))
+		assert.ErrorIs(t, err, cause)
+		assert.ErrorContains(t, err, "boom")
+		assert.Equal(t, []string{"audit", "panicky", "all"}, calls)
+	})
+
+	t.Run("비동기 모드는 계좌별 순서대로 전달", func(t *testing.T) {
+		var failed []string
+		var mu sync.Mutex
+		bus := NewInMemoryEventBus(
+			WithAsync(AsyncConfig{Workers: 3, BufferSize: 2}),
+			WithErrorHandler(func(subscriber string, e domain.Event, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				failed = append(failed, subscriber)
+			}))
+
+		received := make(map[string][]int64)
+		bus.Subscribe(string(domain.MoneyDeposited),
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				mu.Lock()
+				defer mu.Unlock()
+				received[e.AccountID] = append(received[e.AccountID], e.Version)
+				return nil
+			}))
+		bus.SubscribeNamed(string(domain.MoneyDeposited), "failing",
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				return errors.New("failed")
+			}))
+
+		var events []domain.Event
+		for v := int64(1); v <= 20; v++ {
+			for _, id := range []string{"account-1", "account-2", "account-3"} {
+				events = append(events, event(id, v))
+			}
+		}
+		assert.NoError(t, bus.PublishAll(ctx, events))
+		assert.NoError(t, bus.Close())
+
+		for _, id := range []string{"account-1", "account-2", "account-3"} {
+			assert.Len(t, received[id], 20)
+			for i, v := range received[id] {
+				assert.Equal(t, int64(i+1), v)
+			}
+		}
+		assert.Len(t, failed, len(events))
+		assert.ErrorIs(t, bus.Publish(ctx, event("account-1", 21)), ErrBusClosed)
+	})
+
+	t.Run("큐가 가득 차 대기 중인 Publish 가 있어도 Close 는 멈추지 않음", func(t *testing.T) {
+		bus := NewInMemoryEventBus(WithAsync(AsyncConfig{Workers: 1, BufferSize: 1}))
+
+		started := make(chan struct{}, 1)
+		release := make(chan struct{})
+		var mu sync.Mutex
+		var handled []int64
+		bus.SubscribeNamed(string(domain.MoneyDeposited), "slow",
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				started <- struct{}{}
+				<-release
+				mu.Lock()
+				defer mu.Unlock()
+				handled = append(handled, e.Version)
+				return nil
+			}))
+
+		// 1번은 워커가 처리 중, 2번은 큐에 대기, 3번은 큐가 가득 차서 대기
+		assert.NoError(t, bus.Publish(ctx, event("account-1", 1)))
+		<-started
+		assert.NoError(t, bus.Publish(ctx, event("account-1", 2)))
+		published := make(chan error, 1)
+		go func() { published <- bus.Publish(ctx, event("account-1", 3)) }()
+		time.Sleep(20 * time.Millisecond)
+
+		closed := make(chan error, 1)
+		go func() { closed <- bus.Close() }()
+
+		select {
+		case err := <-published:
+			assert.ErrorIs(t, err, ErrBusClosed)
+		case <-time.After(time.Second):
+			t.Fatal("blocked Publish did not return after Close")
+		}
+
+		subscribed := make(chan struct{})
+		go func() {
+			bus.Subscribe(string(domain.MoneyWithdrawn), domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				return nil
+			}))
+			close(subscribed)
+		}()
+		select {
+		case <-subscribed:
+		case <-time.After(time.Second):
+			t.Fatal("Subscribe blocked while closing")
+		}
+
+		close(release)
+		<-started
+		select {
+		case err := <-closed:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Close did not return")
+		}
+		assert.Equal(t, []int64{1, 2}, handled)
+	})
+	t.Run("비동기 모드는 발행자의 트랜잭션을 구독자에게 넘기지 않음", func(t *testing.T) {
+		bus := NewInMemoryEventBus(WithAsync(AsyncConfig{Workers: 1, BufferSize: 1}))
+		tx := make(chan any, 1)
+		bus.Subscribe(string(domain.MoneyDeposited),
+			domain.EventHandlerFunc(func(ctx context.Context, e domain.Event) error {
+				tx <- ctx.Value(domain.TxKey)
+				return nil
+			}))
+
+		txCtx := context.WithValue(ctx, domain.TxKey, "relay-tx")
+		assert.NoError(t, bus.Publish(txCtx, event("account-1", 1)))
+		assert.NoError(t, bus.Close())
+		assert.Nil(t, <-tx)
+	})
+}
@@ -0,0 +1,138 @@
+package eventhandler
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware 이름이 붙은 핸들러를 감싸서 공통 처리를 추가
+type Middleware func(name string, next domain.EventHandler) domain.EventHandler
+
+// Chain 핸들러에 미들웨어들을 적용 (앞에 있는 미들웨어가 가장 바깥쪽)
+func Chain(name string, handler domain.EventHandler, middlewares ...Middleware) domain.EventHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](name, handler)
+	}
+	return handler
+}
+
+// Recovery 핸들러의 panic 을 에러로 바꿔서 컨슈머가 죽지 않게 함
+func Recovery() Middleware {
+	return func(name string, next domain.EventHandler) domain.EventHandler {
+		return domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Handler panic recovered: Handler=%s, EventID=%s, Panic=%v\n%s",
+						name, event.ID, r, debug.Stack())
+					err = fmt.Errorf("handler %s panicked: %v", name, r)
+				}
+			}()
+			return next.Handle(ctx, event)
+		})
+	}
+}
+
+// Tracing 핸들러 실행마다 스팬 생성
+func Tracing() Middleware {
+	tracer := otel.Tracer("event-handler")
+	return func(name string, next domain.EventHandler) domain.EventHandler {
+		return domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+			ctx, span := tracer.Start(ctx, "handle-"+name)
+			defer span.End()
+
+			span.SetAttributes(
+				attribute.String("event.id", event.ID),
+				attribute.String("event.type", event.EventType),
+				attribute.String("event.account_id", event.AccountID),
+			)
+
+			err := next.Handle(ctx, event)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		})
+	}
+}
+
+// Logging 핸들러 실행 결과와 소요 시간 로깅
+func Logging() Middleware {
+	return func(name string, next domain.EventHandler) domain.EventHandler {
+		return domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+			start := time.Now()
+			err := next.Handle(ctx, event)
+			if err != nil {
+				log.Printf("Event handler failed: Handler=%s, Type=%s, EventID=%s, Duration=%s, Error=%v",
+					name, event.EventType, event.ID, time.Since(start), err)
+				return err
+			}
+			log.Printf("Event handled: Handler=%s, Type=%s, EventID=%s, Duration=%s",
+				name, event.EventType, event.ID, time.Since(start))
+			return nil
+		})
+	}
+}
+
+// Metrics 핸들러별 처리 횟수와 소요 시간을 OpenTelemetry 메트릭으로 기록
+func Metrics() Middleware {
+	meter := otel.Meter("event-handler")
+	handled, _ := meter.Int64Counter("event_handler.handled",
+		metric.WithDescription("number of handled events"))
+	duration, _ := meter.Float64Histogram("event_handler.duration",
+		metric.WithDescription("event handler duration"), metric.WithUnit("ms"))
+
+	return func(name string, next domain.EventHandler) domain.EventHandler {
+		return domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+			start := time.Now()
+			err := next.Handle(ctx, event)
+
+			attrs := metric.WithAttributes(
+				attribute.String("handler", name),
+				attribute.String("event.type", event.EventType),
+				attribute.Bool("success", err == nil),
+			)
+			handled.Add(ctx, 1, attrs)
+			duration.Record(ctx, float64(time.Since(start).Microseconds())/1000, attrs)
+			return err
+		})
+	}
+}
+
+// Retry 재시도 정책에 따라 핸들러를 다시 실행, 모두 실패하면 시도 횟수를 담은 *RetryError 반환
+func Retry(policy RetryPolicy) Middleware {
+	return func(name string, next domain.EventHandler) domain.EventHandler {
+		return domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+			attempts, err := policy.Do(ctx, func() error {
+				return next.Handle(ctx, event)
+			})
+			if err != nil {
+				return &RetryError{Handler: name, Attempts: attempts, Err: err}
+			}
+			return nil
+		})
+	}
+}
+
+// RetryError 재시도를 모두 실패한 핸들러 에러
+type RetryError struct {
+	Handler  string
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("handler %s failed after %d attempts: %v", e.Handler, e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
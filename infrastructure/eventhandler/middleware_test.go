@@ -0,0 +1,67 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+	event := domain.Event{ID: "event-1", EventType: string(domain.MoneyDeposited)}
+
+	t.Run("Chain 순서", func(t *testing.T) {
+		var calls []string
+		record := func(label string) Middleware {
+			return func(name string, next domain.EventHandler) domain.EventHandler {
+				return domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+					calls = append(calls, label+":"+name)
+					return next.Handle(ctx, event)
+				})
+			}
+		}
+		handler := domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+			calls = append(calls, "handler")
+			return nil
+		})
+
+		err := Chain("audit", handler, record("outer"), record("inner")).Handle(ctx, event)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"outer:audit", "inner:audit", "handler"}, calls)
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		handler := domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+			panic("boom")
+		})
+
+		err := Chain("audit", handler, Recovery()).Handle(ctx, event)
+		assert.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+		cause := errors.New("permanent")
+		calls := 0
+		handler := domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+			calls++
+			if calls == 1 {
+				panic("boom")
+			}
+			return cause
+		})
+
+		err := Chain("audit", handler, Retry(policy), Recovery()).Handle(ctx, event)
+
+		var retryErr *RetryError
+		assert.ErrorAs(t, err, &retryErr)
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, "audit", retryErr.Handler)
+		assert.Equal(t, 3, retryErr.Attempts)
+		assert.Equal(t, 3, calls)
+	})
+}
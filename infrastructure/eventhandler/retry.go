@@ -0,0 +1,59 @@
+package eventhandler
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy 핸들러 실패 시 재시도 횟수와 지수 백오프 설정
+type RetryPolicy struct {
+	MaxAttempts    int // 첫 시도를 포함한 최대 시도 횟수
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultRetryPolicy 핸들러별 정책이 없을 때 사용하는 기본 재시도 정책
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// Backoff attempt 번째 시도가 실패한 뒤 다음 시도까지 대기할 시간 (attempt 는 1부터)
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= p.Multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(backoff)
+}
+
+// Do 성공하거나 시도 횟수를 다 쓸 때까지 fn 을 실행하고, 실제 시도 횟수와 마지막 에러를 반환
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) (int, error) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return attempt, nil
+		}
+		if attempt == maxAttempts {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(p.Backoff(attempt)):
+		}
+	}
+	return maxAttempts, err
+}
@@ -0,0 +1,40 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("Backoff", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+		assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+		assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+		assert.Equal(t, 300*time.Millisecond, policy.Backoff(3))
+		assert.Equal(t, 300*time.Millisecond, policy.Backoff(4))
+	})
+
+	t.Run("Do", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+
+		calls := 0
+		attempts, err := policy.Do(context.Background(), func() error {
+			calls++
+			if calls < 2 {
+				return errors.New("temporary")
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+
+		cause := errors.New("permanent")
+		attempts, err = policy.Do(context.Background(), func() error { return cause })
+		assert.ErrorIs(t, err, cause)
+		assert.Equal(t, 3, attempts)
+	})
+}
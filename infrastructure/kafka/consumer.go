@@ -3,64 +3,265 @@ package infraKafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/eventhandler"
 	"log"
+	"time"
 )
 
+// failedMessageBackoff 재시도를 모두 실패한 메시지를 다시 처리하기 전 대기 시간
+const failedMessageBackoff = time.Second
+
+// errInvalidMessage 다시 읽어도 처리할 수 없는 메시지 (디코딩 실패 등)
+var errInvalidMessage = errors.New("invalid message")
+
+// deadLetterSink 실패한 메시지를 받는 곳 (DeadLetterPublisher)
+type deadLetterSink interface {
+	Publish(msg *kafka.Message, cause error, attempts int) error
+}
+
+// partitionFlow kafka.Consumer 의 파티션 일시정지/재개 메서드
+type partitionFlow interface {
+	Assignment() ([]kafka.TopicPartition, error)
+	Pause(partitions []kafka.TopicPartition) error
+	Resume(partitions []kafka.TopicPartition) error
+}
+
 type EventConsumer struct {
-	consumer  *kafka.Consumer
-	topic     string
-	handlers  map[string]domain.EventHandler
-	isRunning bool
+	consumer     *kafka.Consumer
+	flow         partitionFlow
+	topic        string
+	handlers     map[string][]*registeredHandler
+	middlewares  []eventhandler.Middleware
+	isRunning    bool
+	commitPolicy CommitPolicy
+	committer    *offsetCommitter
+	retryPolicy  eventhandler.RetryPolicy
+	deadLetters  deadLetterSink
+	concurrency  ConcurrencyConfig
+	pool         *workerPool
+	tracker      *offsetTracker
+	inFlight     int
+	paused       bool
+	ctx          context.Context
+	generation   int                // 파티션 할당 세대, 파티션을 잃을 때마다 증가
+	genCtx       context.Context    // 현재 세대에 읽은 메시지를 처리하는 컨텍스트
+	cancelGen    context.CancelFunc // 파티션을 잃으면 이전 세대의 처리를 중단
+	done         chan struct{}
+}
+
+// registeredHandler 이벤트 타입별로 등록된 이름 있는 핸들러와 재시도 정책
+type registeredHandler struct {
+	name        string
+	handler     domain.EventHandler
+	retryPolicy eventhandler.RetryPolicy
+	chain       domain.EventHandler // 미들웨어가 적용된 핸들러 (Subscribe 에서 생성)
+}
+
+// ConsumerOption NewEventConsumer 설정 옵션
+type ConsumerOption func(*EventConsumer)
+
+// WithCommitPolicy 오프셋 커밋 배치 크기/주기 설정
+func WithCommitPolicy(policy CommitPolicy) ConsumerOption {
+	return func(ec *EventConsumer) {
+		ec.commitPolicy = policy
+	}
+}
+
+// WithDefaultRetryPolicy 핸들러별 정책을 지정하지 않은 핸들러에 적용할 재시도 정책
+func WithDefaultRetryPolicy(policy eventhandler.RetryPolicy) ConsumerOption {
+	return func(ec *EventConsumer) {
+		ec.retryPolicy = policy
+	}
 }
 
-func NewEventConsumer(brokers string, groupID string, topic string) (*EventConsumer, error) {
+// WithDeadLetterQueue 재시도를 모두 실패했거나 처리할 수 없는 메시지를 보낼 데드레터 퍼블리셔 설정
+// 설정하지 않으면 실패한 메시지는 성공할 때까지 다시 처리
+func WithDeadLetterQueue(deadLetters *DeadLetterPublisher) ConsumerOption {
+	return func(ec *EventConsumer) {
+		if deadLetters != nil {
+			ec.deadLetters = deadLetters
+		}
+	}
+}
+
+// WithConcurrency 워커 수와 처리 중인 메시지 최대 수 설정
+func WithConcurrency(cfg ConcurrencyConfig) ConsumerOption {
+	return func(ec *EventConsumer) {
+		ec.concurrency = cfg
+	}
+}
+
+// HandlerOption RegisterHandler 설정 옵션
+type HandlerOption func(*registeredHandler)
+
+// WithRetryPolicy 핸들러별 재시도 정책 설정
+func WithRetryPolicy(policy eventhandler.RetryPolicy) HandlerOption {
+	return func(rh *registeredHandler) {
+		rh.retryPolicy = policy
+	}
+}
+
+// NewEventConsumer 자동 커밋을 끄고 핸들러가 성공한 메시지의 오프셋만 커밋 (at-least-once)
+func NewEventConsumer(brokers string, groupID string, topic string, opts ...ConsumerOption) (*EventConsumer, error) {
 	c, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers":       brokers,
-		"group.id":                groupID,
-		"auto.offset.reset":       "earliest",
-		"enable.auto.commit":      true,
-		"auto.commit.interval.ms": 1000,
-		"client.id":               "account-service-consumer",
+		"bootstrap.servers":        brokers,
+		"group.id":                 groupID,
+		"auto.offset.reset":        "earliest",
+		"enable.auto.commit":       false,
+		"enable.auto.offset.store": false,
+		"client.id":                "account-service-consumer",
 	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consumer: %v", err)
 	}
 
-	return &EventConsumer{
-		consumer:  c,
-		topic:     topic,
-		handlers:  make(map[string]domain.EventHandler),
-		isRunning: false,
-	}, nil
+	ec := &EventConsumer{
+		consumer:     c,
+		flow:         c,
+		topic:        topic,
+		handlers:     make(map[string][]*registeredHandler),
+		isRunning:    false,
+		commitPolicy: DefaultCommitPolicy,
+		retryPolicy:  eventhandler.DefaultRetryPolicy,
+		concurrency:  DefaultConcurrency,
+		tracker:      newOffsetTracker(),
+	}
+	for _, opt := range opts {
+		opt(ec)
+	}
+	ec.concurrency = ec.concurrency.normalized()
+	ec.committer = newOffsetCommitter(c, ec.commitPolicy)
+
+	return ec, nil
 }
 
-func (ec *EventConsumer) RegisterHandler(eventType string, handler domain.EventHandler) {
-	ec.handlers[eventType] = handler
+// Use 모든 핸들러의 Handle 호출을 감쌀 미들웨어 추가 (먼저 추가한 것이 바깥쪽), Subscribe 전에 호출
+func (ec *EventConsumer) Use(middlewares ...eventhandler.Middleware) {
+	ec.middlewares = append(ec.middlewares, middlewares...)
+}
+
+// RegisterHandler 이벤트 타입에 이름 있는 핸들러 등록
+// 한 타입에 여러 핸들러를 등록할 수 있고 등록 순서대로 모두 실행, domain.WildcardEventType 이면 모든 이벤트를 받음
+func (ec *EventConsumer) RegisterHandler(eventType string, name string, handler domain.EventHandler, opts ...HandlerOption) error {
+	if name == "" {
+		return fmt.Errorf("handler name is required for event type %s", eventType)
+	}
+	for _, registered := range ec.handlers[eventType] {
+		if registered.name == name {
+			return fmt.Errorf("handler %s is already registered for event type %s", name, eventType)
+		}
+	}
+
+	rh := &registeredHandler{
+		name:        name,
+		handler:     handler,
+		retryPolicy: ec.retryPolicy,
+	}
+	for _, opt := range opts {
+		opt(rh)
+	}
+	ec.handlers[eventType] = append(ec.handlers[eventType], rh)
+	return nil
+}
+
+// buildChains 핸들러마다 미들웨어 체인 생성
+// 재시도는 핸들러별 정책을 쓰므로 Use 로 추가한 미들웨어 안쪽에 두고, panic 도 재시도되도록 그 안에서 복구
+func (ec *EventConsumer) buildChains() {
+	for _, handlers := range ec.handlers {
+		for _, rh := range handlers {
+			middlewares := append(append([]eventhandler.Middleware{}, ec.middlewares...),
+				eventhandler.Retry(rh.retryPolicy), eventhandler.Recovery())
+			rh.chain = eventhandler.Chain(rh.name, rh.handler, middlewares...)
+		}
+	}
+}
+
+// handlersFor 이벤트 타입에 등록된 핸들러와 와일드카드 핸들러
+func (ec *EventConsumer) handlersFor(eventType string) []*registeredHandler {
+	handlers := append([]*registeredHandler{}, ec.handlers[eventType]...)
+	if eventType != domain.WildcardEventType {
+		handlers = append(handlers, ec.handlers[domain.WildcardEventType]...)
+	}
+	return handlers
 }
 
 func (ec *EventConsumer) Subscribe(ctx context.Context) error {
-	if err := ec.consumer.SubscribeTopics([]string{ec.topic}, nil); err != nil {
+	ec.buildChains()
+
+	if err := ec.consumer.SubscribeTopics([]string{ec.topic}, ec.rebalance); err != nil {
 		return fmt.Errorf("failed to subscribe to topic %s: %v", ec.topic, err)
 	}
 
 	ec.isRunning = true
+	ec.done = make(chan struct{})
+	ec.ctx = ctx
+	ec.nextGeneration()
+	ec.pool = newWorkerPool(ec.concurrency, ec.handleMessage)
 	go ec.consumeMessages(ctx)
 	return nil
 }
 
+// rebalance 파티션을 잃기 전에 처리 중인 메시지를 RevokeTimeout 까지 기다리고 끝난 곳까지 오프셋을 커밋
+// 그때까지 끝나지 않은 메시지(데드레터 없이 계속 실패하는 메시지 등)는 중단하고 커밋하지 않아서 새 주인이 다시 처리
+func (ec *EventConsumer) rebalance(c *kafka.Consumer, event kafka.Event) error {
+	if _, ok := event.(kafka.RevokedPartitions); ok {
+		if !ec.awaitCompletions(ec.concurrency.RevokeTimeout) {
+			log.Printf("Revoking partitions with %d messages still in flight, leaving their offsets uncommitted", ec.inFlight)
+		}
+		if err := ec.committer.commit(); err != nil {
+			log.Printf("Error committing offsets on revoke: %v", err)
+		}
+		ec.tracker.reset()
+		ec.nextGeneration()
+		ec.paused = false
+	}
+	return nil
+}
+
+// nextGeneration 이전 세대의 처리를 중단하고 새 파티션 할당 세대를 시작
+func (ec *EventConsumer) nextGeneration() {
+	if ec.cancelGen != nil {
+		ec.cancelGen()
+	}
+	ec.generation++
+	ec.genCtx, ec.cancelGen = context.WithCancel(ec.ctx)
+}
+
+// consumeMessages 메시지를 읽어 워커에 나눠주고, 완료된 메시지의 오프셋을 커밋하는 폴링 루프
+// 오프셋 추적과 커밋은 모두 이 고루틴에서만 일어남
 func (ec *EventConsumer) consumeMessages(ctx context.Context) {
+	defer close(ec.done)
+	defer func() {
+		ec.pool.stop()
+		ec.drainCompletions()
+		if err := ec.committer.commit(); err != nil {
+			log.Printf("Error committing offsets on shutdown: %v", err)
+		}
+	}()
+
 	for ec.isRunning {
 		select {
 		case <-ctx.Done(): // 컨텍스트 취소됐을때의 처리
 			ec.isRunning = false
 			return
 		default:
+			ec.drainCompletions()
+
+			// 처리 중인 메시지가 가득 차면 파티션을 멈추고 폴링은 계속 (backpressure)
+			// 폴링을 멈추면 max.poll.interval.ms 를 넘겨 그룹에서 쫓겨나고 리밸런스도 처리하지 못함
+			ec.applyBackpressure()
+
+			if err := ec.committer.maybeCommit(); err != nil {
+				log.Printf("Error committing offsets: %v", err)
+			}
+
 			// 일반적 메세지 처리
-			msg, err := ec.consumer.ReadMessage(100)
+			msg, err := ec.consumer.ReadMessage(100 * time.Millisecond)
 			if err != nil {
 				if !err.(kafka.Error).IsTimeout() {
 					log.Printf("Error reading message: %v", err)
@@ -68,33 +269,173 @@ func (ec *EventConsumer) consumeMessages(ctx context.Context) {
 				continue
 			}
 
-			if err := ec.processMessage(ctx, msg); err != nil {
-				log.Printf("Error processing message: %v", err)
-			}
+			ec.tracker.dispatched(msg.TopicPartition)
+			ec.inFlight++
+			ec.pool.dispatch(job{ctx: ec.genCtx, msg: msg, generation: ec.generation})
+		}
+	}
+}
 
+// applyBackpressure 처리 중인 메시지 수에 따라 할당된 파티션을 멈추거나 다시 읽기 시작
+func (ec *EventConsumer) applyBackpressure() {
+	full := ec.inFlight >= ec.concurrency.MaxInFlight
+	if full == ec.paused {
+		return
+	}
+
+	partitions, err := ec.flow.Assignment()
+	if err != nil {
+		log.Printf("Error getting assigned partitions: %v", err)
+		return
+	}
+	if full {
+		err = ec.flow.Pause(partitions)
+	} else {
+		err = ec.flow.Resume(partitions)
+	}
+	if err != nil {
+		log.Printf("Error pausing/resuming partitions: %v", err)
+		return
+	}
+	ec.paused = full
+}
+
+// drainCompletions 이미 끝난 메시지를 반영
+func (ec *EventConsumer) drainCompletions() {
+	for ec.inFlight > 0 {
+		select {
+		case c := <-ec.pool.completions:
+			ec.complete(c)
+		default:
+			return
+		}
+	}
+}
+
+// awaitCompletions 처리 중인 메시지가 모두 끝날 때까지 최대 timeout 동안 대기, 모두 끝났으면 true
+func (ec *EventConsumer) awaitCompletions(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for ec.inFlight > 0 {
+		select {
+		case c := <-ec.pool.completions:
+			ec.complete(c)
+		case <-timer.C:
+			return false
 		}
 	}
+	return true
 }
 
-func (ec *EventConsumer) processMessage(ctx context.Context, msg *kafka.Message) error {
+// complete 처리된 메시지까지 빈틈 없이 끝났으면 다음 오프셋을 저장
+// 이전 할당 세대의 메시지는 이미 다른 컨슈머에게 넘어간 파티션이므로 오프셋을 저장하지 않음
+func (ec *EventConsumer) complete(c completion) {
+	ec.inFlight--
+	if !c.processed || c.generation != ec.generation {
+		return
+	}
+	if next, ok := ec.tracker.completed(c.msg.TopicPartition); ok {
+		if err := ec.committer.markProcessed(next); err != nil {
+			log.Printf("Error storing offset: %v", err)
+		}
+	}
+}
+
+// handleMessage 워커에서 메시지 하나를 처리, 종료나 파티션 회수로 중단되면 false
+// 실패한 메시지를 넘길 수 없으면 같은 계좌의 다음 메시지보다 먼저 끝나야 하므로 그 자리에서 다시 시도
+func (ec *EventConsumer) handleMessage(ctx context.Context, msg *kafka.Message) bool {
+	for {
+		attempts, err := ec.processMessage(ctx, msg)
+		if err == nil {
+			return true
+		}
+
+		log.Printf("Error processing message: %v", err)
+		// 종료나 파티션 회수로 재시도가 중단된 메시지는 넘기지 않고 커밋하지 않은 채로 두어 다음에 다시 처리
+		if ctx.Err() != nil {
+			return false
+		}
+		if ec.deadLetter(msg, err, attempts) {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(failedMessageBackoff):
+		}
+	}
+}
+
+// deadLetter 실패한 메시지를 넘겨도 되는지 결정 (true 면 완료로 보고 다음 메시지로 진행)
+// 데드레터 토픽이 있으면 그쪽으로 보내고, 없으면 처리할 수 없는 메시지만 로그를 남기고 건너뜀
+func (ec *EventConsumer) deadLetter(msg *kafka.Message, cause error, attempts int) bool {
+	if ec.deadLetters == nil {
+		return errors.Is(cause, errInvalidMessage)
+	}
+	if err := ec.deadLetters.Publish(msg, cause, attempts); err != nil {
+		log.Printf("Error sending message to dead letter topic: %v", err)
+		return false
+	}
+	return true
+}
+
+// processMessage 메시지를 디코딩해서 등록된 핸들러를 모두 실행하고 실패한 핸들러의 최대 시도 횟수를 반환
+// 하나가 실패해도 나머지 핸들러는 실행하며, 메시지를 다시 처리하면 모든 핸들러가 다시 실행되므로 핸들러는 멱등해야 함
+func (ec *EventConsumer) processMessage(ctx context.Context, msg *kafka.Message) (int, error) {
 	var event domain.Event
 	if err := json.Unmarshal(msg.Value, &event); err != nil {
-		return fmt.Errorf("failed to unmarshal event: %v", err)
+		return 0, fmt.Errorf("%w: failed to unmarshal event: %v", errInvalidMessage, err)
+	}
+
+	// 인박스 중복 제거가 이벤트 ID 기준이므로 ID 없는 이벤트는 처리하지 않음
+	if event.ID == "" {
+		return 0, fmt.Errorf("%w: %v: %s event of %s", errInvalidMessage, domain.ErrMissingEventID, event.EventType, event.AccountID)
+	}
+
+	// 이전 스키마 버전으로 발행된 이벤트는 현재 버전으로 변환
+	event, err := domain.DefaultEventRegistry.Upcast(event)
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to upcast event: %v", errInvalidMessage, err)
+	}
+
+	// 페이로드가 등록된 타입으로 디코딩되지 않는 이벤트는 핸들러에 넘기지 않음
+	if _, err := event.DecodeData(); err != nil {
+		return 0, fmt.Errorf("%w: invalid event payload: %v", errInvalidMessage, err)
 	}
 
 	eventType := event.GetEventType()
-	handler, exists := ec.handlers[eventType]
-	if !exists {
-		return fmt.Errorf("no handler registered for event type: %s", eventType)
+	handlers := ec.handlersFor(eventType)
+	if len(handlers) == 0 {
+		return 0, fmt.Errorf("%w: no handler registered for event type: %s", errInvalidMessage, eventType)
 	}
 
-	if err := handler.Handle(ctx, event); err != nil {
-		return fmt.Errorf("handler failed for event type: %v", eventType)
+	attempts := 0
+	var errs []error
+	for _, rh := range handlers {
+		err := rh.chain.Handle(ctx, event)
+		if err == nil {
+			continue
+		}
+
+		var retryErr *eventhandler.RetryError
+		if errors.As(err, &retryErr) && retryErr.Attempts > attempts {
+			attempts = retryErr.Attempts
+		}
+		errs = append(errs, err)
 	}
-	return nil
+	if len(errs) > 0 {
+		return attempts, fmt.Errorf("handlers failed for event type %s: %w", eventType, errors.Join(errs...))
+	}
+	return attempts, nil
 }
 
+// Close 소비 루프가 마지막 오프셋을 커밋하고 끝날 때까지 기다린 뒤 컨슈머 종료
 func (ec *EventConsumer) Close() error {
 	ec.isRunning = false
+	if ec.done != nil {
+		<-ec.done
+	}
 	return ec.consumer.Close()
 }
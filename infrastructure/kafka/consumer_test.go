@@ -0,0 +1,198 @@
+package infraKafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/eventhandler"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventConsumerHandlers(t *testing.T) {
+	newConsumer := func() *EventConsumer {
+		return &EventConsumer{
+			handlers:    make(map[string][]*registeredHandler),
+			retryPolicy: eventhandler.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, Multiplier: 2},
+		}
+	}
+
+	event, err := domain.NewEvent("account-1", domain.MoneyDepositedData{AccountID: "account-1", Amount: 100})
+	assert.NoError(t, err)
+	value, _ := json.Marshal(event)
+	msg := &kafka.Message{Value: value}
+
+	t.Run("같은 타입의 핸들러와 와일드카드 핸들러를 모두 실행", func(t *testing.T) {
+		ec := newConsumer()
+		var calls []string
+		handler := func(name string) domain.EventHandler {
+			return domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+				calls = append(calls, name)
+				return nil
+			})
+		}
+
+		assert.NoError(t, ec.RegisterHandler(string(domain.MoneyDeposited), "projection", handler("projection")))
+		assert.NoError(t, ec.RegisterHandler(string(domain.MoneyDeposited), "audit", handler("audit")))
+		assert.NoError(t, ec.RegisterHandler(domain.WildcardEventType, "logger", handler("logger")))
+		assert.NoError(t, ec.RegisterHandler(string(domain.MoneyWithdrawn), "other", handler("other")))
+		assert.Error(t, ec.RegisterHandler(string(domain.MoneyDeposited), "audit", handler("audit")))
+		ec.buildChains()
+
+		_, err := ec.processMessage(context.Background(), msg)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"projection", "audit", "logger"}, calls)
+	})
+
+	t.Run("실패한 핸들러가 있어도 나머지는 실행", func(t *testing.T) {
+		ec := newConsumer()
+		cause := errors.New("audit failed")
+		projected := false
+
+		ec.RegisterHandler(string(domain.MoneyDeposited), "audit",
+			domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error { return cause }))
+		ec.RegisterHandler(string(domain.MoneyDeposited), "projection",
+			domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+				projected = true
+				return nil
+			}))
+		ec.buildChains()
+
+		attempts, err := ec.processMessage(context.Background(), msg)
+		assert.ErrorIs(t, err, cause)
+		assert.NotErrorIs(t, err, errInvalidMessage)
+		assert.Equal(t, 2, attempts)
+		assert.True(t, projected)
+	})
+
+	t.Run("재시도 중에 종료되면 데드레터로 보내지 않고 오프셋도 저장하지 않음", func(t *testing.T) {
+		ec := newConsumer()
+		ec.retryPolicy = eventhandler.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second, Multiplier: 2}
+		deadLetters := &fakeDeadLetterSink{}
+		store := &fakeOffsetStore{}
+		ec.deadLetters = deadLetters
+		ec.committer = newOffsetCommitter(store, DefaultCommitPolicy)
+		ec.tracker = newOffsetTracker()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ec.RegisterHandler(string(domain.MoneyDeposited), "projection",
+			domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+				cancel()
+				return errors.New("projection failed")
+			}))
+		ec.buildChains()
+
+		msg := &kafka.Message{Value: value, TopicPartition: kafka.TopicPartition{Offset: 7}}
+		ec.tracker.dispatched(msg.TopicPartition)
+		ec.inFlight++
+		ec.complete(completion{msg: msg, processed: ec.handleMessage(ctx, msg)})
+
+		assert.Empty(t, deadLetters.published)
+		assert.Empty(t, store.stored)
+		assert.NoError(t, ec.committer.commit())
+		assert.Zero(t, store.commits)
+	})
+}
+
+func TestEventConsumerFlowControl(t *testing.T) {
+	value := func(accountID string) []byte {
+		event, _ := domain.NewEvent(accountID, domain.MoneyDepositedData{AccountID: accountID, Amount: 100})
+		value, _ := json.Marshal(event)
+		return value
+	}
+
+	t.Run("파티션을 잃을 때 끝나지 않는 메시지는 기다리다 중단하고 커밋하지 않음", func(t *testing.T) {
+		store := &fakeOffsetStore{}
+		ec := &EventConsumer{
+			handlers:    make(map[string][]*registeredHandler),
+			retryPolicy: eventhandler.RetryPolicy{MaxAttempts: 1},
+			concurrency: ConcurrencyConfig{Workers: 2, MaxInFlight: 10, RevokeTimeout: 50 * time.Millisecond},
+			committer:   newOffsetCommitter(store, DefaultCommitPolicy),
+			tracker:     newOffsetTracker(),
+			ctx:         context.Background(),
+		}
+		// 데드레터 없이 계속 실패하는 메시지처럼 파티션을 잃어 취소될 때까지 끝나지 않는 핸들러
+		ec.RegisterHandler(string(domain.MoneyDeposited), "projection",
+			domain.EventHandlerFunc(func(ctx context.Context, event domain.Event) error {
+				if event.AccountID == "stuck" {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return nil
+			}))
+		ec.buildChains()
+		ec.nextGeneration()
+		ec.pool = newWorkerPool(ec.concurrency, ec.handleMessage)
+		defer ec.pool.stop()
+
+		dispatch := func(accountID string, offset kafka.Offset) {
+			msg := &kafka.Message{Key: []byte(accountID), Value: value(accountID), TopicPartition: kafka.TopicPartition{Offset: offset}}
+			ec.tracker.dispatched(msg.TopicPartition)
+			ec.inFlight++
+			ec.pool.dispatch(job{ctx: ec.genCtx, msg: msg, generation: ec.generation})
+		}
+		dispatch("account-1", 5)
+		dispatch("stuck", 6)
+
+		started := time.Now()
+		assert.NoError(t, ec.rebalance(nil, kafka.RevokedPartitions{}))
+		assert.Less(t, time.Since(started), time.Second)
+
+		// 끝난 5번까지만 커밋하고 중단된 6번은 커밋하지 않음
+		assert.Equal(t, []kafka.Offset{6}, store.stored)
+		assert.True(t, ec.awaitCompletions(time.Second))
+		assert.Equal(t, []kafka.Offset{6}, store.stored)
+	})
+
+	t.Run("처리 중인 메시지가 가득 차면 파티션을 멈추고 줄어들면 다시 읽음", func(t *testing.T) {
+		flow := &fakePartitionFlow{}
+		ec := &EventConsumer{flow: flow, concurrency: ConcurrencyConfig{MaxInFlight: 2}}
+
+		ec.inFlight = 1
+		ec.applyBackpressure()
+		assert.Zero(t, flow.pauses)
+
+		ec.inFlight = 2
+		ec.applyBackpressure()
+		ec.applyBackpressure()
+		assert.Equal(t, 1, flow.pauses)
+
+		ec.inFlight = 1
+		ec.applyBackpressure()
+		assert.Equal(t, 1, flow.resumes)
+		assert.False(t, ec.paused)
+	})
+}
+
+type fakePartitionFlow struct {
+	pauses  int
+	resumes int
+}
+
+func (f *fakePartitionFlow) Assignment() ([]kafka.TopicPartition, error) {
+	return []kafka.TopicPartition{{Partition: 0}}, nil
+}
+
+func (f *fakePartitionFlow) Pause(partitions []kafka.TopicPartition) error {
+	f.pauses++
+	return nil
+}
+
+func (f *fakePartitionFlow) Resume(partitions []kafka.TopicPartition) error {
+	f.resumes++
+	return nil
+}
+
+type fakeDeadLetterSink struct {
+	published []*kafka.Message
+}
+
+func (f *fakeDeadLetterSink) Publish(msg *kafka.Message, cause error, attempts int) error {
+	f.published = append(f.published, msg)
+	return nil
+}
@@ -0,0 +1,94 @@
+package infraKafka
+
+import (
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"log"
+	"strconv"
+	"time"
+)
+
+// 데드레터 메시지 헤더 키
+const (
+	HeaderDLQError             = "dlq-error"
+	HeaderDLQOriginalTopic     = "dlq-original-topic"
+	HeaderDLQOriginalPartition = "dlq-original-partition"
+	HeaderDLQOriginalOffset    = "dlq-original-offset"
+	HeaderDLQAttempts          = "dlq-attempts"
+	HeaderDLQFailedAt          = "dlq-failed-at"
+)
+
+// DeadLetterPublisher 재시도를 모두 실패한 메시지를 원본 그대로 데드레터 토픽으로 보냄
+type DeadLetterPublisher struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+func NewDeadLetterPublisher(brokers string, topic string) (*DeadLetterPublisher, error) {
+	p, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": brokers,
+		"client.id":         "account-service-dlq-producer",
+		"acks":              "all",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dead letter producer: %v", err)
+	}
+
+	return &DeadLetterPublisher{
+		producer: p,
+		topic:    topic,
+	}, nil
+}
+
+// Publish 실패 원인과 원본 위치, 시도 횟수를 헤더에 담아 전송하고 전달 확인까지 대기
+func (p *DeadLetterPublisher) Publish(msg *kafka.Message, cause error, attempts int) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+6)
+	headers = append(headers, msg.Headers...)
+	headers = append(headers, deadLetterHeaders(msg, cause, attempts)...)
+
+	dlqMsg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &p.topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+
+	deliveryChan := make(chan kafka.Event, 1)
+	if err := p.producer.Produce(dlqMsg, deliveryChan); err != nil {
+		return fmt.Errorf("error queuing dead letter: %v", err)
+	}
+
+	e := <-deliveryChan
+	if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+		return fmt.Errorf("dead letter delivery failed: %v", m.TopicPartition.Error)
+	}
+
+	log.Printf("Message sent to dead letter topic %s: Offset=%v, Attempts=%d, Error=%v",
+		p.topic, msg.TopicPartition.Offset, attempts, cause)
+	return nil
+}
+
+// Close 프로듀서 종료
+func (p *DeadLetterPublisher) Close() {
+	p.producer.Flush(10 * 1000)
+	p.producer.Close()
+}
+
+func deadLetterHeaders(msg *kafka.Message, cause error, attempts int) []kafka.Header {
+	var originalTopic string
+	if msg.TopicPartition.Topic != nil {
+		originalTopic = *msg.TopicPartition.Topic
+	}
+
+	return []kafka.Header{
+		{Key: HeaderDLQError, Value: []byte(cause.Error())},
+		{Key: HeaderDLQOriginalTopic, Value: []byte(originalTopic)},
+		{Key: HeaderDLQOriginalPartition, Value: []byte(strconv.Itoa(int(msg.TopicPartition.Partition)))},
+		{Key: HeaderDLQOriginalOffset, Value: []byte(strconv.FormatInt(int64(msg.TopicPartition.Offset), 10))},
+		{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		{Key: HeaderDLQFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	}
+}
@@ -0,0 +1,243 @@
+package infraKafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go-eventsourcing-patterns/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderDLQReplayedFrom 데드레터 토픽에서 재처리된 메시지에 붙는 헤더 (partition:offset)
+const HeaderDLQReplayedFrom = "dlq-replayed-from"
+
+// DeadLetterMessage 데드레터 토픽의 메시지와 헤더에 담긴 실패 정보
+type DeadLetterMessage struct {
+	Partition         int32
+	Offset            int64
+	Event             *domain.Event // 디코딩에 실패하면 nil
+	DecodeError       error
+	Error             string
+	OriginalTopic     string
+	OriginalPartition int32
+	OriginalOffset    int64
+	Attempts          int
+	FailedAt          time.Time
+
+	message *kafka.Message
+}
+
+// Position partition:offset 형태의 데드레터 위치
+func (m DeadLetterMessage) Position() string {
+	return fmt.Sprintf("%d:%d", m.Partition, m.Offset)
+}
+
+// DeadLetterFilter 재처리할 메시지 선택 조건 (비어 있는 조건은 무시)
+type DeadLetterFilter struct {
+	Positions []string // partition:offset
+	EventType string
+	AccountID string
+}
+
+func (f DeadLetterFilter) Matches(m DeadLetterMessage) bool {
+	if len(f.Positions) > 0 {
+		found := false
+		for _, p := range f.Positions {
+			if p == m.Position() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.EventType != "" && (m.Event == nil || m.Event.EventType != f.EventType) {
+		return false
+	}
+	if f.AccountID != "" && (m.Event == nil || m.Event.AccountID != f.AccountID) {
+		return false
+	}
+	return true
+}
+
+// DeadLetterReader 데드레터 토픽을 처음부터 현재 끝까지 읽음 (오프셋은 커밋하지 않음)
+type DeadLetterReader struct {
+	consumer *kafka.Consumer
+	topic    string
+}
+
+func NewDeadLetterReader(brokers string, topic string) (*DeadLetterReader, error) {
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  brokers,
+		"group.id":           "account-service-dlq-inspector",
+		"enable.auto.commit": false,
+		"client.id":          "account-service-dlq-inspector",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dead letter reader: %v", err)
+	}
+
+	return &DeadLetterReader{
+		consumer: c,
+		topic:    topic,
+	}, nil
+}
+
+// ReadAll 모든 파티션을 읽기 시작한 시점의 high watermark 까지 읽어서 반환
+func (r *DeadLetterReader) ReadAll(timeout time.Duration) ([]DeadLetterMessage, error) {
+	metadata, err := r.consumer.GetMetadata(&r.topic, false, int(timeout.Milliseconds()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata for %s: %v", r.topic, err)
+	}
+	topicMetadata, ok := metadata.Topics[r.topic]
+	if !ok || topicMetadata.Error.Code() != kafka.ErrNoError {
+		return nil, fmt.Errorf("topic %s not found: %v", r.topic, topicMetadata.Error)
+	}
+
+	remaining := make(map[int32]int64)
+	var assignment []kafka.TopicPartition
+	for _, p := range topicMetadata.Partitions {
+		low, high, err := r.consumer.QueryWatermarkOffsets(r.topic, p.ID, int(timeout.Milliseconds()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query offsets of partition %d: %v", p.ID, err)
+		}
+		if high > low {
+			remaining[p.ID] = high
+			assignment = append(assignment, kafka.TopicPartition{
+				Topic:     &r.topic,
+				Partition: p.ID,
+				Offset:    kafka.Offset(low),
+			})
+		}
+	}
+	if len(assignment) == 0 {
+		return nil, nil
+	}
+	if err := r.consumer.Assign(assignment); err != nil {
+		return nil, fmt.Errorf("failed to assign partitions: %v", err)
+	}
+
+	var messages []DeadLetterMessage
+	for len(remaining) > 0 {
+		msg, err := r.consumer.ReadMessage(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dead letter: %v", err)
+		}
+		messages = append(messages, parseDeadLetter(msg))
+
+		partition := msg.TopicPartition.Partition
+		if int64(msg.TopicPartition.Offset)+1 >= remaining[partition] {
+			delete(remaining, partition)
+		}
+	}
+	return messages, nil
+}
+
+// Close 컨슈머 종료
+func (r *DeadLetterReader) Close() error {
+	return r.consumer.Close()
+}
+
+func parseDeadLetter(msg *kafka.Message) DeadLetterMessage {
+	m := DeadLetterMessage{
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		message:   msg,
+	}
+
+	for _, h := range msg.Headers {
+		value := string(h.Value)
+		switch h.Key {
+		case HeaderDLQError:
+			m.Error = value
+		case HeaderDLQOriginalTopic:
+			m.OriginalTopic = value
+		case HeaderDLQOriginalPartition:
+			partition, _ := strconv.ParseInt(value, 10, 32)
+			m.OriginalPartition = int32(partition)
+		case HeaderDLQOriginalOffset:
+			m.OriginalOffset, _ = strconv.ParseInt(value, 10, 64)
+		case HeaderDLQAttempts:
+			m.Attempts, _ = strconv.Atoi(value)
+		case HeaderDLQFailedAt:
+			m.FailedAt, _ = time.Parse(time.RFC3339, value)
+		}
+	}
+
+	var event domain.Event
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		m.DecodeError = err
+		return m
+	}
+	upcasted, err := domain.DefaultEventRegistry.Upcast(event)
+	if err != nil {
+		m.DecodeError = err
+		m.Event = &event
+		return m
+	}
+	m.Event = &upcasted
+	return m
+}
+
+// DeadLetterReplayer 데드레터 메시지를 원래 토픽으로 다시 보냄
+type DeadLetterReplayer struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+func NewDeadLetterReplayer(brokers string, topic string) (*DeadLetterReplayer, error) {
+	p, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": brokers,
+		"client.id":         "account-service-dlq-replayer",
+		"acks":              "all",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replay producer: %v", err)
+	}
+
+	return &DeadLetterReplayer{
+		producer: p,
+		topic:    topic,
+	}, nil
+}
+
+// Replay 원본 키/값 그대로 보내고, 실패 정보 헤더는 빼고 재처리 위치만 남김
+func (r *DeadLetterReplayer) Replay(m DeadLetterMessage) error {
+	var headers []kafka.Header
+	for _, h := range m.message.Headers {
+		if !strings.HasPrefix(h.Key, "dlq-") {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers, kafka.Header{Key: HeaderDLQReplayedFrom, Value: []byte(m.Position())})
+
+	msg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{
+			Topic:     &r.topic,
+			Partition: kafka.PartitionAny,
+		},
+		Key:     m.message.Key,
+		Value:   m.message.Value,
+		Headers: headers,
+	}
+
+	deliveryChan := make(chan kafka.Event, 1)
+	if err := r.producer.Produce(msg, deliveryChan); err != nil {
+		return fmt.Errorf("error queuing replay: %v", err)
+	}
+
+	e := <-deliveryChan
+	if ev, ok := e.(*kafka.Message); ok && ev.TopicPartition.Error != nil {
+		return fmt.Errorf("replay delivery failed: %v", ev.TopicPartition.Error)
+	}
+	return nil
+}
+
+// Close 프로듀서 종료
+func (r *DeadLetterReplayer) Close() {
+	r.producer.Flush(10 * 1000)
+	r.producer.Close()
+}
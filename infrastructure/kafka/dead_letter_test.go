@@ -0,0 +1,45 @@
+package infraKafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterHeaders(t *testing.T) {
+	topic := "account-events"
+	msg := &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 2, Offset: 42}}
+
+	headers := map[string]string{}
+	for _, h := range deadLetterHeaders(msg, errors.New("db down"), 3) {
+		headers[h.Key] = string(h.Value)
+	}
+
+	assert.Equal(t, "db down", headers[HeaderDLQError])
+	assert.Equal(t, "account-events", headers[HeaderDLQOriginalTopic])
+	assert.Equal(t, "2", headers[HeaderDLQOriginalPartition])
+	assert.Equal(t, "42", headers[HeaderDLQOriginalOffset])
+	assert.Equal(t, "3", headers[HeaderDLQAttempts])
+	assert.NotEmpty(t, headers[HeaderDLQFailedAt])
+}
+
+func TestDeadLetterFilter(t *testing.T) {
+	topic := "account-events.dlq"
+	msg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0, Offset: 7},
+		Value:          []byte(`{"ID":"e1","AccountID":"acc-1","EventType":"MoneyDeposited","SchemaVersion":1}`),
+		Headers:        deadLetterHeaders(&kafka.Message{}, errors.New("db down"), 3),
+	}
+	m := parseDeadLetter(msg)
+	assert.NoError(t, m.DecodeError)
+	assert.Equal(t, "0:7", m.Position())
+	assert.Equal(t, "db down", m.Error)
+	assert.Equal(t, 3, m.Attempts)
+
+	assert.True(t, DeadLetterFilter{}.Matches(m))
+	assert.True(t, DeadLetterFilter{Positions: []string{"0:7"}, AccountID: "acc-1"}.Matches(m))
+	assert.False(t, DeadLetterFilter{Positions: []string{"0:8"}}.Matches(m))
+	assert.False(t, DeadLetterFilter{EventType: "MoneyWithdrawn"}.Matches(m))
+}
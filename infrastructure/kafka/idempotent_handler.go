@@ -0,0 +1,39 @@
+package infraKafka
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+	"log"
+)
+
+// IdempotentHandler 인박스에 처리 기록을 남기면서 핸들러를 실행해서 같은 이벤트가 재전달되면 건너뜀
+// 인박스 기록과 핸들러의 쓰기가 한 트랜잭션이라 핸들러가 실패하면 기록도 함께 롤백됨
+type IdempotentHandler struct {
+	name    string
+	handler domain.EventHandler
+	inbox   domain.Inbox
+	uow     domain.UnitOfWork
+}
+
+func NewIdempotentHandler(name string, handler domain.EventHandler, inbox domain.Inbox, uow domain.UnitOfWork) *IdempotentHandler {
+	return &IdempotentHandler{
+		name:    name,
+		handler: handler,
+		inbox:   inbox,
+		uow:     uow,
+	}
+}
+
+func (h *IdempotentHandler) Handle(ctx context.Context, event domain.Event) error {
+	return h.uow.RunInTransaction(ctx, func(tctx context.Context) error {
+		first, err := h.inbox.Record(tctx, h.name, event.ID)
+		if err != nil {
+			return err
+		}
+		if !first {
+			log.Printf("Skipping already processed event: Handler=%s, EventID=%s", h.name, event.ID)
+			return nil
+		}
+		return h.handler.Handle(tctx, event)
+	})
+}
@@ -0,0 +1,66 @@
+package infraKafka
+
+import (
+	"context"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeInbox 트랜잭션이 실패하면 기록을 되돌리는 인박스
+type fakeInbox struct {
+	committed map[string]bool
+	pending   map[string]bool
+}
+
+func (f *fakeInbox) Record(ctx context.Context, handlerName string, eventID string) (bool, error) {
+	key := handlerName + "/" + eventID
+	if f.committed[key] || f.pending[key] {
+		return false, nil
+	}
+	f.pending[key] = true
+	return true, nil
+}
+
+func (f *fakeInbox) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.pending = map[string]bool{}
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	for k := range f.pending {
+		f.committed[k] = true
+	}
+	return nil
+}
+
+func (f *fakeInbox) GetTransactionContext(ctx context.Context) context.Context { return ctx }
+
+type countingHandler struct {
+	calls int
+	err   error
+}
+
+func (h *countingHandler) Handle(ctx context.Context, event domain.Event) error {
+	h.calls++
+	return h.err
+}
+
+func TestIdempotentHandler(t *testing.T) {
+	ctx := context.Background()
+	inbox := &fakeInbox{committed: map[string]bool{}}
+	inner := &countingHandler{err: errors.New("db down")}
+	handler := NewIdempotentHandler("projection", inner, inbox, inbox)
+	event := domain.Event{ID: "e1"}
+
+	// 실패한 처리는 기록되지 않아서 재전달 시 다시 실행됨
+	assert.Error(t, handler.Handle(ctx, event))
+	inner.err = nil
+	assert.NoError(t, handler.Handle(ctx, event))
+	assert.Equal(t, 2, inner.calls)
+
+	// 성공한 이벤트가 재전달되면 건너뜀
+	assert.NoError(t, handler.Handle(ctx, event))
+	assert.Equal(t, 2, inner.calls)
+}
@@ -0,0 +1,77 @@
+package infraKafka
+
+import (
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"time"
+)
+
+// CommitPolicy 처리 완료된 오프셋을 브로커에 커밋하는 주기
+// BatchSize 개가 쌓이거나 마지막 커밋 후 Interval 이 지나면 커밋
+type CommitPolicy struct {
+	BatchSize int
+	Interval  time.Duration
+}
+
+// DefaultCommitPolicy 기본 커밋 정책
+var DefaultCommitPolicy = CommitPolicy{
+	BatchSize: 100,
+	Interval:  time.Second,
+}
+
+// offsetStore kafka.Consumer 의 오프셋 저장/커밋 메서드
+type offsetStore interface {
+	StoreOffsets(offsets []kafka.TopicPartition) ([]kafka.TopicPartition, error)
+	Commit() ([]kafka.TopicPartition, error)
+}
+
+// offsetCommitter 처리가 끝난 지점의 오프셋만 저장해두고 정책에 따라 묶어서 커밋
+type offsetCommitter struct {
+	store      offsetStore
+	policy     CommitPolicy
+	pending    int
+	lastCommit time.Time
+}
+
+func newOffsetCommitter(store offsetStore, policy CommitPolicy) *offsetCommitter {
+	return &offsetCommitter{
+		store:      store,
+		policy:     policy,
+		lastCommit: time.Now(),
+	}
+}
+
+// markProcessed 다음에 읽을 오프셋을 저장 (아직 커밋은 아님)
+func (oc *offsetCommitter) markProcessed(next kafka.TopicPartition) error {
+	if _, err := oc.store.StoreOffsets([]kafka.TopicPartition{next}); err != nil {
+		return fmt.Errorf("failed to store offset: %v", err)
+	}
+	oc.pending++
+	return nil
+}
+
+// maybeCommit 커밋 정책을 만족하면 저장된 오프셋을 커밋
+func (oc *offsetCommitter) maybeCommit() error {
+	if oc.pending == 0 {
+		return nil
+	}
+	if oc.pending < oc.policy.BatchSize && time.Since(oc.lastCommit) < oc.policy.Interval {
+		return nil
+	}
+	return oc.commit()
+}
+
+// commit 저장된 오프셋을 바로 커밋 (종료, 리밸런스 시 사용)
+func (oc *offsetCommitter) commit() error {
+	if oc.pending == 0 {
+		return nil
+	}
+	if _, err := oc.store.Commit(); err != nil {
+		if kerr, ok := err.(kafka.Error); !ok || kerr.Code() != kafka.ErrNoOffset {
+			return fmt.Errorf("failed to commit offsets: %v", err)
+		}
+	}
+	oc.pending = 0
+	oc.lastCommit = time.Now()
+	return nil
+}
@@ -0,0 +1,90 @@
+package infraKafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOffsetStore struct {
+	stored  []kafka.Offset
+	commits int
+}
+
+func (f *fakeOffsetStore) StoreOffsets(offsets []kafka.TopicPartition) ([]kafka.TopicPartition, error) {
+	for _, tp := range offsets {
+		f.stored = append(f.stored, tp.Offset)
+	}
+	return nil, nil
+}
+
+func (f *fakeOffsetStore) Commit() ([]kafka.TopicPartition, error) {
+	f.commits++
+	return nil, nil
+}
+
+func TestOffsetCommitter(t *testing.T) {
+	next := func(offset int64) kafka.TopicPartition {
+		return kafka.TopicPartition{Offset: kafka.Offset(offset + 1)}
+	}
+
+	t.Run("BatchSize", func(t *testing.T) {
+		store := &fakeOffsetStore{}
+		oc := newOffsetCommitter(store, CommitPolicy{BatchSize: 2, Interval: time.Hour})
+
+		assert.NoError(t, oc.markProcessed(next(0)))
+		assert.NoError(t, oc.maybeCommit())
+		assert.Equal(t, 0, store.commits)
+
+		assert.NoError(t, oc.markProcessed(next(1)))
+		assert.NoError(t, oc.maybeCommit())
+		assert.Equal(t, 1, store.commits)
+		assert.Equal(t, []kafka.Offset{1, 2}, store.stored)
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		store := &fakeOffsetStore{}
+		oc := newOffsetCommitter(store, CommitPolicy{BatchSize: 100, Interval: time.Millisecond})
+
+		// 처리한 메시지가 없으면 주기가 지나도 커밋하지 않음
+		time.Sleep(2 * time.Millisecond)
+		assert.NoError(t, oc.maybeCommit())
+		assert.Equal(t, 0, store.commits)
+
+		assert.NoError(t, oc.markProcessed(next(0)))
+		assert.NoError(t, oc.maybeCommit())
+		assert.Equal(t, 1, store.commits)
+	})
+}
+
+func TestOffsetTracker(t *testing.T) {
+	topic := "account-events"
+	tp := func(partition int32, offset int64) kafka.TopicPartition {
+		return kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: kafka.Offset(offset)}
+	}
+
+	tracker := newOffsetTracker()
+	for _, offset := range []int64{10, 11, 12} {
+		tracker.dispatched(tp(0, offset))
+	}
+	tracker.dispatched(tp(1, 5))
+
+	// 11 이 먼저 끝나도 10 이 끝나기 전에는 커밋 지점이 움직이지 않음
+	_, ok := tracker.completed(tp(0, 11))
+	assert.False(t, ok)
+
+	next, ok := tracker.completed(tp(0, 10))
+	assert.True(t, ok)
+	assert.Equal(t, kafka.Offset(12), next.Offset)
+
+	next, ok = tracker.completed(tp(1, 5))
+	assert.True(t, ok)
+	assert.Equal(t, int32(1), next.Partition)
+	assert.Equal(t, kafka.Offset(6), next.Offset)
+
+	next, ok = tracker.completed(tp(0, 12))
+	assert.True(t, ok)
+	assert.Equal(t, kafka.Offset(13), next.Offset)
+}
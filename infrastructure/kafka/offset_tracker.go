@@ -0,0 +1,80 @@
+package infraKafka
+
+import (
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"sort"
+)
+
+// offsetTracker 파티션별로 처리 중인 오프셋을 추적해서 빈틈 없이 완료된 지점까지만 커밋 가능하게 함
+// 서로 다른 계좌의 메시지는 병렬로 처리되므로 같은 파티션 안에서도 완료 순서가 뒤바뀔 수 있음
+type offsetTracker struct {
+	partitions map[partitionKey]*partitionOffsets
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+type partitionOffsets struct {
+	inFlight []int64 // 아직 커밋 지점에 포함되지 않은 오프셋 (오름차순)
+	done     map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		partitions: make(map[partitionKey]*partitionOffsets),
+	}
+}
+
+func keyOf(tp kafka.TopicPartition) partitionKey {
+	var topic string
+	if tp.Topic != nil {
+		topic = *tp.Topic
+	}
+	return partitionKey{topic: topic, partition: tp.Partition}
+}
+
+// dispatched 워커로 보낸 메시지의 오프셋 기록
+func (t *offsetTracker) dispatched(tp kafka.TopicPartition) {
+	key := keyOf(tp)
+	p, ok := t.partitions[key]
+	if !ok {
+		p = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[key] = p
+	}
+
+	offset := int64(tp.Offset)
+	i := sort.Search(len(p.inFlight), func(i int) bool { return p.inFlight[i] >= offset })
+	p.inFlight = append(p.inFlight, 0)
+	copy(p.inFlight[i+1:], p.inFlight[i:])
+	p.inFlight[i] = offset
+}
+
+// completed 처리 완료를 기록하고, 커밋 지점이 앞으로 움직였으면 저장할 다음 오프셋을 반환
+func (t *offsetTracker) completed(tp kafka.TopicPartition) (kafka.TopicPartition, bool) {
+	p, ok := t.partitions[keyOf(tp)]
+	if !ok {
+		return kafka.TopicPartition{}, false
+	}
+	p.done[int64(tp.Offset)] = true
+
+	advanced := int64(-1)
+	for len(p.inFlight) > 0 && p.done[p.inFlight[0]] {
+		advanced = p.inFlight[0]
+		delete(p.done, p.inFlight[0])
+		p.inFlight = p.inFlight[1:]
+	}
+	if advanced < 0 {
+		return kafka.TopicPartition{}, false
+	}
+
+	next := tp
+	next.Offset = kafka.Offset(advanced + 1)
+	return next, true
+}
+
+// reset 파티션 할당이 바뀌면 추적 정보를 비움
+func (t *offsetTracker) reset() {
+	t.partitions = make(map[partitionKey]*partitionOffsets)
+}
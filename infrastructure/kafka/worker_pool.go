@@ -0,0 +1,103 @@
+package infraKafka
+
+import (
+	"context"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// ConcurrencyConfig 메시지 병렬 처리 설정
+type ConcurrencyConfig struct {
+	Workers       int           // 워커 수, 같은 키(계좌 ID)의 메시지는 항상 같은 워커에서 순서대로 처리
+	MaxInFlight   int           // 처리 중인 메시지 최대 수, 가득 차면 파티션을 멈추고 폴링만 계속
+	RevokeTimeout time.Duration // 파티션을 잃을 때 처리 중인 메시지를 기다리는 최대 시간, 넘기면 중단하고 커밋하지 않음
+}
+
+// DefaultConcurrency 기본 병렬 처리 설정
+var DefaultConcurrency = ConcurrencyConfig{
+	Workers:       4,
+	MaxInFlight:   100,
+	RevokeTimeout: 10 * time.Second,
+}
+
+// normalized 0 이하인 값을 최솟값으로 보정
+func (c ConcurrencyConfig) normalized() ConcurrencyConfig {
+	if c.Workers < 1 {
+		c.Workers = 1
+	}
+	if c.MaxInFlight < 1 {
+		c.MaxInFlight = 1
+	}
+	if c.RevokeTimeout <= 0 {
+		c.RevokeTimeout = DefaultConcurrency.RevokeTimeout
+	}
+	return c
+}
+
+// job 워커에 넘기는 메시지와 파티션 할당 세대의 컨텍스트 (파티션을 잃으면 취소됨)
+type job struct {
+	ctx        context.Context
+	msg        *kafka.Message
+	generation int
+}
+
+// completion 워커가 메시지 처리를 끝냈다는 알림 (processed 가 false 면 종료나 파티션 회수로 중단된 것)
+type completion struct {
+	msg        *kafka.Message
+	generation int
+	processed  bool
+}
+
+// workerPool 메시지 키 해시로 워커를 골라서 계좌별 순서를 지키면서 계좌 간에는 병렬 처리
+type workerPool struct {
+	queues      []chan job
+	completions chan completion
+	wg          sync.WaitGroup
+}
+
+func newWorkerPool(cfg ConcurrencyConfig, process func(ctx context.Context, msg *kafka.Message) bool) *workerPool {
+	cfg = cfg.normalized()
+
+	p := &workerPool{
+		queues: make([]chan job, cfg.Workers),
+		// 파티션을 멈추기 전에 받아둔 메시지 때문에 MaxInFlight 를 잠깐 넘을 수 있지만
+		// 소비 루프가 매번 완료 알림을 비우므로 워커가 오래 막히지 않음
+		completions: make(chan completion, cfg.MaxInFlight),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan job, cfg.MaxInFlight)
+		p.wg.Add(1)
+		go func(queue chan job) {
+			defer p.wg.Done()
+			for j := range queue {
+				p.completions <- completion{msg: j.msg, generation: j.generation, processed: process(j.ctx, j.msg)}
+			}
+		}(p.queues[i])
+	}
+	return p
+}
+
+// dispatch 메시지를 키에 해당하는 워커 큐에 넣음
+func (p *workerPool) dispatch(j job) {
+	p.queues[p.workerFor(j.msg)] <- j
+}
+
+// workerFor 키가 없으면 파티션 기준으로 워커 선택
+func (p *workerPool) workerFor(msg *kafka.Message) int {
+	if len(msg.Key) == 0 {
+		return int(msg.TopicPartition.Partition) % len(p.queues)
+	}
+	h := fnv.New32a()
+	h.Write(msg.Key)
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+// stop 큐를 닫고 워커들이 끝날 때까지 대기
+func (p *workerPool) stop() {
+	for _, q := range p.queues {
+		close(q)
+	}
+	p.wg.Wait()
+}
@@ -0,0 +1,41 @@
+package infraKafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	var mu sync.Mutex
+	processed := map[string][]kafka.Offset{}
+
+	pool := newWorkerPool(ConcurrencyConfig{Workers: 3, MaxInFlight: 30}, func(ctx context.Context, msg *kafka.Message) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		processed[string(msg.Key)] = append(processed[string(msg.Key)], msg.TopicPartition.Offset)
+		return true
+	})
+
+	keys := []string{"acc-1", "acc-2", "acc-3"}
+	for offset := 0; offset < 30; offset++ {
+		pool.dispatch(job{ctx: context.Background(), msg: &kafka.Message{
+			Key:            []byte(keys[offset%len(keys)]),
+			TopicPartition: kafka.TopicPartition{Offset: kafka.Offset(offset)},
+		}})
+	}
+	pool.stop()
+	assert.Len(t, pool.completions, 30)
+
+	// 같은 계좌의 메시지는 읽은 순서대로 처리됨
+	for i, key := range keys {
+		offsets := processed[key]
+		assert.Len(t, offsets, 10)
+		for j, offset := range offsets {
+			assert.Equal(t, kafka.Offset(j*len(keys)+i), offset)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"context"
+	"log"
+)
+
+// LogNotifier 알림을 로그로만 남기는 domain.Notifier 구현 (로컬 개발용)
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, accountID string, message string) error {
+	log.Printf("Notification: AccountID=%s, Message=%s", accountID, message)
+	return nil
+}
@@ -0,0 +1,87 @@
+package eventstoretest
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// InsertFunc 저장소의 버전/ID 확인을 건너뛰고 events[from:] 를 firstVersion 부터 INSERT 하는 어댑터별 훅
+// INSERT 가 실패하면 저장소의 Save 와 같은 방식으로 에러를 변환해서 반환
+type InsertFunc func(accountID string, events []domain.Event, from int, firstVersion int64) error
+
+// RunInsertErrors INSERT 가 유니크 제약에 걸리면 배치 안에서 원인이 된 이벤트를 알려주는지 확인
+// Save 는 INSERT 전에 버전과 ID 를 확인하므로, insert 로 확인을 건너뛰어 동시에 저장한 요청과 부딪힌 상황을 만듦
+func RunInsertErrors(t *testing.T, store domain.EventStore, insert InsertFunc) {
+	accountID := newAccountID()
+	stored := depositsOf(t, accountID, 3)
+	assert.NoError(t, store.Save(context.Background(), accountID, 0, stored))
+
+	t.Run("이미 저장된 버전", func(t *testing.T) {
+		events := depositsOf(t, accountID, 5)
+		err := insert(accountID, events, 2, 2)
+
+		var eventErr *domain.EventError
+		assert.ErrorAs(t, err, &eventErr)
+		assert.ErrorIs(t, err, domain.ErrConcurrencyConflict)
+		assert.Equal(t, 2, eventErr.Index)
+		assert.Equal(t, events[2].ID, eventErr.EventID)
+	})
+
+	t.Run("이미 저장된 ID", func(t *testing.T) {
+		events := depositsOf(t, accountID, 5)
+		events[3].ID = stored[1].ID
+		err := insert(accountID, events, 2, 10)
+
+		var eventErr *domain.EventError
+		assert.ErrorAs(t, err, &eventErr)
+		assert.ErrorIs(t, err, domain.ErrDuplicateEventID)
+		assert.Equal(t, 3, eventErr.Index)
+	})
+}
+
+// SaveFunc 새 계좌 스트림에 events 를 처음부터 저장
+type SaveFunc func(accountID string, events []domain.Event) error
+
+// BenchmarkSave 다중 행 INSERT 로 저장하는 store.Save 와 이벤트마다 INSERT 하는 rowByRow 비교
+func BenchmarkSave(b *testing.B, store domain.EventStore, rowByRow SaveFunc) {
+	batched := func(accountID string, events []domain.Event) error {
+		return store.Save(context.Background(), accountID, 0, events)
+	}
+
+	for _, size := range []int{10, 100, 1000} {
+		for _, bm := range []struct {
+			name string
+			save SaveFunc
+		}{
+			{"row-by-row", rowByRow},
+			{"batched", batched},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", bm.name, size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					accountID := "bench-" + uuid.New().String()
+					events := depositsOf(b, accountID, size)
+					b.StartTimer()
+
+					if err := bm.save(accountID, events); err != nil {
+						b.Fatalf("failed to save events: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// depositsOf 계좌에 10 씩 입금하는 이벤트 n 개
+func depositsOf(t testing.TB, accountID string, n int) []domain.Event {
+	amounts := make([]int64, n)
+	for i := range amounts {
+		amounts[i] = 10
+	}
+	return deposits(t, accountID, amounts...)
+}
@@ -0,0 +1,567 @@
+// Package eventstoretest 모든 domain.EventStore 구현이 같은 동작을 하는지 확인하는 적합성 테스트
+//
+// 각 어댑터의 _test.go 에서 저장소를 만드는 함수와 함께 Run 을 호출
+//
+//	func TestEventStoreConformance(t *testing.T) {
+//		eventstoretest.Run(t, func(t *testing.T) eventstoretest.Fixture {
+//			db := memory.NewMemoryDB()
+//			return eventstoretest.Fixture{Store: memory.NewEventStore(db), TxManager: db}
+//		})
+//	}
+package eventstoretest
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go-eventsourcing-patterns/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// largeBatchSize 한 번에 저장하는 큰 배치의 이벤트 수
+const largeBatchSize = 1000
+
+// Fixture 테스트할 이벤트 저장소와 그 저장소가 참여하는 트랜잭션 매니저
+type Fixture struct {
+	Store     domain.EventStore
+	TxManager domain.TransactionManager
+}
+
+// Factory 테스트마다 새 Fixture 생성
+// 저장소를 공유해도 되도록 테스트는 매번 새 계좌 ID 를 사용함
+type Factory func(t *testing.T) Fixture
+
+// Run 적합성 테스트 실행
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, f Fixture)
+	}{
+		{"EmptyStream", testEmptyStream},
+		{"Ordering", testOrdering},
+		{"LoadAfter", testLoadAfter},
+		{"StreamsAreIsolated", testStreamsAreIsolated},
+		{"ConcurrencyConflict", testConcurrencyConflict},
+		{"ConcurrentWriters", testConcurrentWriters},
+		{"MissingEventID", testMissingEventID},
+		{"DuplicateEventID", testDuplicateEventID},
+		{"LargeBatch", testLargeBatch},
+		{"TransactionCommit", testTransactionCommit},
+		{"TransactionRollback", testTransactionRollback},
+		{"ReadAll", testReadAll},
+		{"ReadAllPaging", testReadAllPaging},
+		{"LoadRange", testLoadRange},
+		{"LoadRangeByTime", testLoadRangeByTime},
+		{"Stream", testStream},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, factory(t))
+		})
+	}
+}
+
+func newAccountID() string {
+	return "conformance-" + uuid.New().String()
+}
+
+// deposits 계좌에 amounts 만큼 입금하는 이벤트들
+func deposits(t testing.TB, accountID string, amounts ...int64) []domain.Event {
+	events := make([]domain.Event, 0, len(amounts))
+	for _, amount := range amounts {
+		event, err := domain.NewEvent(accountID, domain.MoneyDepositedData{AccountID: accountID, Amount: amount})
+		if err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// assertStream 스트림이 버전 1 부터 빈틈 없이 순서대로 있고 입금액이 amounts 와 같은지 확인
+func assertStream(t *testing.T, events []domain.Event, accountID string, firstVersion int64, amounts ...int64) {
+	t.Helper()
+	if !assert.Len(t, events, len(amounts)) {
+		return
+	}
+	for i, event := range events {
+		assert.Equal(t, accountID, event.AccountID)
+		assert.Equal(t, firstVersion+int64(i), event.Version)
+		assert.Equal(t, string(domain.MoneyDeposited), event.EventType)
+
+		data, err := event.DecodeData()
+		if assert.NoError(t, err) {
+			assert.Equal(t, amounts[i], data.(domain.MoneyDepositedData).Amount)
+		}
+	}
+}
+
+func testEmptyStream(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	events, err := f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = f.Store.LoadAfter(ctx, accountID, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	// 빈 스트림의 현재 버전은 0
+	assert.ErrorIs(t, f.Store.Save(ctx, accountID, 1, deposits(t, accountID, 10)), domain.ErrConcurrencyConflict)
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, deposits(t, accountID, 10)))
+}
+
+func testOrdering(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	first := deposits(t, accountID, 10, 20, 30)
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, first))
+	assert.NoError(t, f.Store.Save(ctx, accountID, 3, deposits(t, accountID, 40)))
+	assert.NoError(t, f.Store.Save(ctx, accountID, 4, deposits(t, accountID, 50, 60)))
+
+	// Save 는 전달한 이벤트에 저장된 버전을 채움
+	for i, event := range first {
+		assert.Equal(t, int64(i+1), event.Version)
+	}
+
+	events, err := f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 1, 10, 20, 30, 40, 50, 60)
+	if assert.Len(t, events, 6) {
+		assert.Equal(t, first[0].ID, events[0].ID)
+	}
+}
+
+func testLoadAfter(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, deposits(t, accountID, 10, 20, 30, 40)))
+
+	events, err := f.Store.LoadAfter(ctx, accountID, 2)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 3, 30, 40)
+
+	events, err = f.Store.LoadAfter(ctx, accountID, 4)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = f.Store.LoadAfter(ctx, accountID, 0)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 1, 10, 20, 30, 40)
+}
+
+func testStreamsAreIsolated(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	a, b := newAccountID(), newAccountID()
+
+	assert.NoError(t, f.Store.Save(ctx, a, 0, deposits(t, a, 10, 20)))
+	assert.NoError(t, f.Store.Save(ctx, b, 0, deposits(t, b, 30)))
+	assert.NoError(t, f.Store.Save(ctx, a, 2, deposits(t, a, 40)))
+
+	events, err := f.Store.Load(ctx, a)
+	assert.NoError(t, err)
+	assertStream(t, events, a, 1, 10, 20, 40)
+
+	events, err = f.Store.Load(ctx, b)
+	assert.NoError(t, err)
+	assertStream(t, events, b, 1, 30)
+}
+
+func testConcurrencyConflict(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, deposits(t, accountID, 10, 20)))
+
+	// 뒤처진 버전, 앞선 버전 모두 충돌
+	assert.ErrorIs(t, f.Store.Save(ctx, accountID, 1, deposits(t, accountID, 30)), domain.ErrConcurrencyConflict)
+	assert.ErrorIs(t, f.Store.Save(ctx, accountID, 3, deposits(t, accountID, 30)), domain.ErrConcurrencyConflict)
+
+	// 충돌한 저장은 아무것도 남기지 않음
+	events, err := f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 1, 10, 20)
+}
+
+func testConcurrentWriters(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, deposits(t, accountID, 10)))
+
+	const writers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		batch := deposits(t, accountID, int64(100+i), int64(200+i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- f.Store.Save(ctx, accountID, 1, batch)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrConcurrencyConflict)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	// 이긴 쪽의 배치만 통째로 저장됨
+	events, err := f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 3) {
+		second, _ := events[1].DecodeData()
+		third, _ := events[2].DecodeData()
+		assert.Equal(t, second.(domain.MoneyDepositedData).Amount+100, third.(domain.MoneyDepositedData).Amount)
+	}
+}
+
+func testMissingEventID(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	events := deposits(t, accountID, 10, 20)
+	events[1].ID = ""
+	err := f.Store.Save(ctx, accountID, 0, events)
+	assert.ErrorIs(t, err, domain.ErrMissingEventID)
+
+	var eventErr *domain.EventError
+	if assert.ErrorAs(t, err, &eventErr) {
+		assert.Equal(t, 1, eventErr.Index)
+	}
+
+	stored, err := f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func testDuplicateEventID(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	t.Run("같은 배치 안에서 중복", func(t *testing.T) {
+		events := deposits(t, accountID, 10, 20, 30)
+		events[2].ID = events[0].ID
+		err := f.Store.Save(ctx, accountID, 0, events)
+		assert.ErrorIs(t, err, domain.ErrDuplicateEventID)
+
+		var eventErr *domain.EventError
+		if assert.ErrorAs(t, err, &eventErr) {
+			assert.Equal(t, 2, eventErr.Index)
+			assert.Equal(t, events[0].ID, eventErr.EventID)
+		}
+	})
+
+	t.Run("이미 저장된 ID", func(t *testing.T) {
+		first := deposits(t, accountID, 10)
+		assert.NoError(t, f.Store.Save(ctx, accountID, 0, first))
+
+		// 다른 계좌 스트림이라도 ID 는 전체 로그에서 유일해야 함
+		otherID := newAccountID()
+		events := deposits(t, otherID, 20, 30, 40)
+		events[1].ID = first[0].ID
+		err := f.Store.Save(ctx, otherID, 0, events)
+		assert.ErrorIs(t, err, domain.ErrDuplicateEventID)
+
+		var eventErr *domain.EventError
+		if assert.ErrorAs(t, err, &eventErr) {
+			assert.Equal(t, 1, eventErr.Index)
+			assert.Equal(t, first[0].ID, eventErr.EventID)
+		}
+
+		stored, err := f.Store.Load(ctx, otherID)
+		assert.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+}
+
+func testLargeBatch(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	amounts := make([]int64, largeBatchSize)
+	for i := range amounts {
+		amounts[i] = int64(i + 1)
+	}
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, deposits(t, accountID, amounts...)))
+
+	events, err := f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 1, amounts...)
+
+	// 여러 페이지에 걸쳐 스트리밍해도 같은 결과
+	streamed := collect(t, f.Store.Stream(ctx, accountID, domain.EventQuery{}))
+	assertStream(t, streamed, accountID, 1, amounts...)
+}
+
+func testTransactionCommit(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	tctx, err := f.TxManager.Begin(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, f.Store.Save(tctx, accountID, 0, deposits(t, accountID, 10)))
+	assert.NoError(t, f.Store.Save(tctx, accountID, 1, deposits(t, accountID, 20)))
+
+	// 같은 트랜잭션 안에서는 커밋 전에도 보임
+	events, err := f.Store.Load(tctx, accountID)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 1, 10, 20)
+	assert.NoError(t, f.TxManager.Commit(tctx))
+
+	events, err = f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 1, 10, 20)
+}
+
+func testTransactionRollback(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, deposits(t, accountID, 10)))
+
+	tctx, err := f.TxManager.Begin(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, f.Store.Save(tctx, accountID, 1, deposits(t, accountID, 20)))
+
+	// 트랜잭션 안에서 충돌한 저장도 에러로 반환
+	err = f.Store.Save(tctx, accountID, 1, deposits(t, accountID, 30))
+	assert.ErrorIs(t, err, domain.ErrConcurrencyConflict)
+	assert.NoError(t, f.TxManager.Rollback(tctx))
+
+	events, err := f.Store.Load(ctx, accountID)
+	assert.NoError(t, err)
+	assertStream(t, events, accountID, 1, 10)
+
+	// 롤백된 버전은 다시 쓸 수 있음
+	assert.NoError(t, f.Store.Save(ctx, accountID, 1, deposits(t, accountID, 40)))
+}
+
+// readAllOf fromPosition 이후 전체 로그를 pageSize 씩 끝까지 읽어서 accountIDs 의 이벤트만 반환
+// 다른 테스트나 데이터와 저장소를 공유해도 되도록 관심 있는 계좌만 걸러냄
+func readAllOf(t *testing.T, f Fixture, fromPosition int64, pageSize int, accountIDs ...string) []domain.Event {
+	t.Helper()
+	wanted := make(map[string]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		wanted[id] = true
+	}
+
+	var result []domain.Event
+	for {
+		page, err := f.Store.ReadAll(context.Background(), fromPosition, pageSize)
+		if !assert.NoError(t, err) || len(page) == 0 {
+			return result
+		}
+		assert.LessOrEqual(t, len(page), pageSize)
+		for _, event := range page {
+			assert.Greater(t, event.Position, fromPosition)
+			fromPosition = event.Position
+			if wanted[event.AccountID] {
+				result = append(result, event)
+			}
+		}
+	}
+}
+
+func testReadAll(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	a, b := newAccountID(), newAccountID()
+
+	first := deposits(t, a, 10, 20)
+	assert.NoError(t, f.Store.Save(ctx, a, 0, first))
+	second := deposits(t, b, 30)
+	assert.NoError(t, f.Store.Save(ctx, b, 0, second))
+	third := deposits(t, a, 40)
+	assert.NoError(t, f.Store.Save(ctx, a, 2, third))
+
+	// Save 는 전달한 이벤트에 저장된 position 을 채우고, 저장 순서대로 증가
+	saved := append(append(append([]domain.Event{}, first...), second...), third...)
+	for i, event := range saved {
+		assert.Greater(t, event.Position, int64(0))
+		if i > 0 {
+			assert.Greater(t, event.Position, saved[i-1].Position)
+		}
+	}
+
+	// Load 로 읽은 이벤트에도 같은 position
+	loaded, err := f.Store.Load(ctx, a)
+	assert.NoError(t, err)
+	if assert.Len(t, loaded, 3) {
+		assert.Equal(t, first[0].Position, loaded[0].Position)
+		assert.Equal(t, third[0].Position, loaded[2].Position)
+	}
+
+	events := readAllOf(t, f, saved[0].Position-1, 100, a, b)
+	if assert.Len(t, events, len(saved)) {
+		for i, event := range events {
+			assert.Equal(t, saved[i].ID, event.ID)
+			assert.Equal(t, saved[i].Position, event.Position)
+			assert.Equal(t, saved[i].Version, event.Version)
+		}
+		data, err := events[2].DecodeData()
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(30), data.(domain.MoneyDepositedData).Amount)
+		}
+	}
+
+	// 마지막 위치 이후에는 이 테스트의 이벤트가 없음
+	assert.Empty(t, readAllOf(t, f, third[0].Position, 100, a, b))
+}
+
+func testReadAllPaging(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	events := deposits(t, accountID, 1, 2, 3, 4, 5, 6, 7)
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, events))
+
+	page, err := f.Store.ReadAll(ctx, events[0].Position-1, 3)
+	assert.NoError(t, err)
+	assert.Len(t, page, 3)
+
+	paged := readAllOf(t, f, events[0].Position-1, 2, accountID)
+	assertStream(t, paged, accountID, 1, 1, 2, 3, 4, 5, 6, 7)
+}
+
+// collect 반복자의 이벤트를 모두 읽음
+func collect(t *testing.T, it domain.EventIterator) []domain.Event {
+	t.Helper()
+	defer it.Close()
+
+	var events []domain.Event
+	for it.Next() {
+		events = append(events, it.Event())
+	}
+	assert.NoError(t, it.Err())
+	return events
+}
+
+// mixedStream 입금 10, 출금 5, 입금 20, 출금 5, 입금 30 순서의 스트림 저장
+func mixedStream(t *testing.T, f Fixture, accountID string) {
+	var events []domain.Event
+	for i, amount := range []int64{10, 5, 20, 5, 30} {
+		var data domain.EventData = domain.MoneyDepositedData{AccountID: accountID, Amount: amount}
+		if i%2 == 1 {
+			data = domain.MoneyWithdrawnData{AccountID: accountID, Amount: amount}
+		}
+		event, err := domain.NewEvent(accountID, data)
+		if err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		events = append(events, event)
+	}
+	assert.NoError(t, f.Store.Save(context.Background(), accountID, 0, events))
+}
+
+func versions(events []domain.Event) []int64 {
+	result := make([]int64, len(events))
+	for i, event := range events {
+		result[i] = event.Version
+	}
+	return result
+}
+
+func testLoadRange(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+	mixedStream(t, f, accountID)
+
+	tests := []struct {
+		name     string
+		query    domain.EventQuery
+		versions []int64
+	}{
+		{"조건 없음", domain.EventQuery{}, []int64{1, 2, 3, 4, 5}},
+		{"버전 범위", domain.EventQuery{FromVersion: 2, ToVersion: 4}, []int64{2, 3, 4}},
+		{"시작 버전만", domain.EventQuery{FromVersion: 4}, []int64{4, 5}},
+		{"이벤트 타입", domain.EventQuery{EventTypes: []domain.EventType{domain.MoneyWithdrawn}}, []int64{2, 4}},
+		{"여러 이벤트 타입", domain.EventQuery{EventTypes: []domain.EventType{domain.MoneyWithdrawn, domain.MoneyDeposited}}, []int64{1, 2, 3, 4, 5}},
+		{"개수 제한", domain.EventQuery{Limit: 2}, []int64{1, 2}},
+		{"조건 조합", domain.EventQuery{FromVersion: 2, EventTypes: []domain.EventType{domain.MoneyDeposited}, Limit: 1}, []int64{3}},
+		{"범위 밖", domain.EventQuery{FromVersion: 6}, []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := f.Store.LoadRange(ctx, accountID, tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.versions, versions(events))
+		})
+	}
+
+	events, err := f.Store.LoadRange(ctx, newAccountID(), domain.EventQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func testLoadRangeByTime(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+
+	// 저장소마다 시각 정밀도가 달라서 마이크로초 단위로 맞춤
+	base := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	events := deposits(t, accountID, 10, 20, 30, 40)
+	for i := range events {
+		events[i].CreatedAt = base.Add(time.Duration(i) * time.Minute)
+	}
+	assert.NoError(t, f.Store.Save(ctx, accountID, 0, events))
+
+	loaded, err := f.Store.LoadRange(ctx, accountID, domain.EventQuery{
+		FromTime: base.Add(time.Minute),
+		ToTime:   base.Add(3 * time.Minute),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, versions(loaded))
+	for _, event := range loaded {
+		assert.True(t, event.CreatedAt.Equal(events[event.Version-1].CreatedAt))
+	}
+
+	loaded, err = f.Store.LoadRange(ctx, accountID, domain.EventQuery{FromTime: base.Add(90 * time.Second)})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 4}, versions(loaded))
+
+	// 다른 시간대로 넘겨도 같은 시각이면 같은 결과
+	loaded, err = f.Store.LoadRange(ctx, accountID, domain.EventQuery{ToTime: base.Add(time.Minute).In(time.FixedZone("KST", 9*60*60))})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, versions(loaded))
+}
+
+func testStream(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	accountID := newAccountID()
+	mixedStream(t, f, accountID)
+
+	events := collect(t, f.Store.Stream(ctx, accountID, domain.EventQuery{}))
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, versions(events))
+
+	events = collect(t, f.Store.Stream(ctx, accountID, domain.EventQuery{
+		FromVersion: 2,
+		EventTypes:  []domain.EventType{domain.MoneyDeposited},
+	}))
+	assert.Equal(t, []int64{3, 5}, versions(events))
+
+	events = collect(t, f.Store.Stream(ctx, accountID, domain.EventQuery{Limit: 3}))
+	assert.Equal(t, []int64{1, 2, 3}, versions(events))
+
+	assert.Empty(t, collect(t, f.Store.Stream(ctx, newAccountID(), domain.EventQuery{})))
+
+	// 닫은 반복자는 더 읽지 않음
+	it := f.Store.Stream(ctx, accountID, domain.EventQuery{})
+	assert.True(t, it.Next())
+	assert.NoError(t, it.Close())
+	assert.False(t, it.Next())
+}
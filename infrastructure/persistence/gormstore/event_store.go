@@ -0,0 +1,77 @@
+// Package gormstore gorm 으로 구현한 이벤트 저장소(Postgres, SQLite)가 함께 쓰는 헬퍼
+package gormstore
+
+import (
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"gorm.io/gorm"
+)
+
+// SaveBatchSize 한 번의 INSERT 로 저장하는 이벤트 수 (바인드 파라미터 수 제한 안쪽)
+const SaveBatchSize = 500
+
+// InsertError events[from:to] 를 INSERT 하다 난 에러를 원인이 된 이벤트의 *domain.EventError 로 변환
+// 유니크 제약 위반이면 배치 안에서 이미 저장된 가장 앞의 버전이나 ID 를 찾음
+// savepoint 가 있으면 조회 전에 그 세이브포인트로 되돌림 (Postgres 는 실패한 트랜잭션에서 조회할 수 없음)
+func InsertError(tx *gorm.DB, savepoint string, accountId string, events []domain.Event, from, to int, err error) error {
+	failed := fmt.Errorf("failed to save events #%d-#%d of %s: %v", from, to-1, accountId, err)
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return failed
+	}
+	if savepoint != "" {
+		if err := tx.RollbackTo(savepoint).Error; err != nil {
+			return failed
+		}
+	}
+
+	batch := events[from:to]
+	var version int64
+	if err := tx.Model(&domain.Event{}).
+		Where("account_id = ? AND version BETWEEN ? AND ?", accountId, batch[0].Version, batch[len(batch)-1].Version).
+		Select("COALESCE(MIN(version), 0)").
+		Scan(&version).Error; err != nil {
+		return failed
+	}
+	if version > 0 {
+		i := from + int(version-batch[0].Version)
+		return &domain.EventError{Index: i, EventID: events[i].ID, EventType: events[i].EventType,
+			Err: fmt.Errorf("%w: version %d of %s already exists", domain.ErrConcurrencyConflict, version, accountId)}
+	}
+
+	if err := CheckExistingIDs(tx, batch); err != nil {
+		var eventErr *domain.EventError
+		if errors.As(err, &eventErr) {
+			eventErr.Index += from
+		}
+		return err
+	}
+	return fmt.Errorf("%w: %v", domain.ErrConcurrencyConflict, failed)
+}
+
+// CheckExistingIDs events 중 이미 저장된 ID 가 있으면 *domain.EventError 반환
+func CheckExistingIDs(tx *gorm.DB, events []domain.Event) error {
+	index := make(map[string]int, len(events))
+	ids := make([]string, len(events))
+	for i, event := range events {
+		index[event.ID] = i
+		ids[i] = event.ID
+	}
+
+	for from := 0; from < len(ids); from += SaveBatchSize {
+		var existing []string
+		if err := tx.Model(&domain.Event{}).
+			Where("id IN ?", ids[from:min(from+SaveBatchSize, len(ids))]).
+			Pluck("id", &existing).Error; err != nil {
+			return fmt.Errorf("failed to check event ids: %v", err)
+		}
+		if len(existing) > 0 {
+			i := index[existing[0]]
+			for _, id := range existing[1:] {
+				i = min(i, index[id])
+			}
+			return &domain.EventError{Index: i, EventID: events[i].ID, EventType: events[i].EventType, Err: domain.ErrDuplicateEventID}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"sort"
+)
+
+type AccountStore struct {
+	db *MemoryDB
+}
+
+func NewAccountStore(db *MemoryDB) *AccountStore {
+	return &AccountStore{
+		db: db,
+	}
+}
+
+// Create 새 계좌 생성
+func (r *AccountStore) Create(ctx context.Context, account *domain.Account) error {
+	return r.db.write(ctx, func(s *state) error {
+		if _, exists := s.accounts[account.ID]; exists {
+			return fmt.Errorf("%w: %s", domain.ErrAccountAlreadyExists, account.ID)
+		}
+		s.accounts[account.ID] = *account
+		return nil
+	})
+}
+
+// FindByID ID로 계좌 조회
+func (r *AccountStore) FindByID(ctx context.Context, id string) (*domain.Account, error) {
+	var account domain.Account
+	err := r.db.read(ctx, func(s *state) error {
+		found, exists := s.accounts[id]
+		if !exists {
+			return fmt.Errorf("%w: %s", domain.ErrAccountNotFound, id)
+		}
+		account = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Update 계좌 정보 업데이트
+func (r *AccountStore) Update(ctx context.Context, account *domain.Account) error {
+	return r.db.write(ctx, func(s *state) error {
+		if _, exists := s.accounts[account.ID]; !exists {
+			return fmt.Errorf("%w: %s", domain.ErrAccountNotFound, account.ID)
+		}
+		s.accounts[account.ID] = *account
+		return nil
+	})
+}
+
+// ListAll 모든 계좌를 생성 순서대로 조회
+func (r *AccountStore) ListAll(ctx context.Context) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	err := r.db.read(ctx, func(s *state) error {
+		accounts = make([]*domain.Account, 0, len(s.accounts))
+		for _, account := range s.accounts {
+			account := account
+			accounts = append(accounts, &account)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].CreatedAt.Equal(accounts[j].CreatedAt) {
+			return accounts[i].ID < accounts[j].ID
+		}
+		return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+	})
+	return accounts, nil
+}
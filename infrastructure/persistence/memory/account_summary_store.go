@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"sort"
+)
+
+type AccountSummaryStore struct {
+	db *MemoryDB
+}
+
+func NewAccountSummaryStore(db *MemoryDB) *AccountSummaryStore {
+	return &AccountSummaryStore{
+		db: db,
+	}
+}
+
+// FindByID 계좌 요약 조회, 없으면 ErrAccountNotFound
+func (r *AccountSummaryStore) FindByID(ctx context.Context, accountID string) (*domain.AccountSummary, error) {
+	var summary domain.AccountSummary
+	err := r.db.read(ctx, func(s *state) error {
+		found, ok := s.summaries[accountID]
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrAccountNotFound, accountID)
+		}
+		summary = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// ListAll 모든 계좌 요약을 생성 순서대로 조회
+func (r *AccountSummaryStore) ListAll(ctx context.Context) ([]domain.AccountSummary, error) {
+	var summaries []domain.AccountSummary
+	err := r.db.read(ctx, func(s *state) error {
+		summaries = make([]domain.AccountSummary, 0, len(s.summaries))
+		for _, summary := range s.summaries {
+			summaries = append(summaries, summary)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].CreatedAt.Equal(summaries[j].CreatedAt) {
+			return summaries[i].AccountID < summaries[j].AccountID
+		}
+		return summaries[i].CreatedAt.Before(summaries[j].CreatedAt)
+	})
+	return summaries, nil
+}
+
+// Save 계좌 요약이 없으면 만들고 있으면 덮어씀
+func (r *AccountSummaryStore) Save(ctx context.Context, summary *domain.AccountSummary) error {
+	return r.db.write(ctx, func(s *state) error {
+		s.summaries[summary.AccountID] = *summary
+		return nil
+	})
+}
+
+// Delete 계좌 요약 삭제
+func (r *AccountSummaryStore) Delete(ctx context.Context, accountIDs []string) error {
+	return r.db.write(ctx, func(s *state) error {
+		for _, id := range accountIDs {
+			delete(s.summaries, id)
+		}
+		return nil
+	})
+}
+
+// Reset 모든 계좌 요약 삭제
+func (r *AccountSummaryStore) Reset(ctx context.Context) error {
+	return r.db.write(ctx, func(s *state) error {
+		s.summaries = make(map[string]domain.AccountSummary)
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+	"sort"
+	"time"
+)
+
+type CheckpointStore struct {
+	db *MemoryDB
+}
+
+func NewCheckpointStore(db *MemoryDB) *CheckpointStore {
+	return &CheckpointStore{
+		db: db,
+	}
+}
+
+// Load 체크포인트 position 조회, 기록이 없으면 0
+func (r *CheckpointStore) Load(ctx context.Context, projectionName string) (int64, error) {
+	var position int64
+	err := r.db.read(ctx, func(s *state) error {
+		position = s.checkpoints[projectionName].Position
+		return nil
+	})
+	return position, err
+}
+
+// Save 체크포인트가 없으면 만들고 있으면 덮어씀
+func (r *CheckpointStore) Save(ctx context.Context, projectionName string, position int64) error {
+	return r.db.write(ctx, func(s *state) error {
+		s.checkpoints[projectionName] = domain.ProjectionCheckpoint{
+			ProjectionName: projectionName,
+			Position:       position,
+			UpdatedAt:      time.Now(),
+		}
+		return nil
+	})
+}
+
+// List 모든 체크포인트를 이름 순서대로 조회
+func (r *CheckpointStore) List(ctx context.Context) ([]domain.ProjectionCheckpoint, error) {
+	var checkpoints []domain.ProjectionCheckpoint
+	err := r.db.read(ctx, func(s *state) error {
+		for _, checkpoint := range s.checkpoints {
+			checkpoints = append(checkpoints, checkpoint)
+		}
+		return nil
+	})
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].ProjectionName < checkpoints[j].ProjectionName
+	})
+	return checkpoints, err
+}
+
+// Delete 체크포인트 삭제, 기록이 없어도 에러 아님
+func (r *CheckpointStore) Delete(ctx context.Context, projectionName string) error {
+	return r.db.write(ctx, func(s *state) error {
+		delete(s.checkpoints, projectionName)
+		return nil
+	})
+}
@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"maps"
+	"sync"
+)
+
+// errTxDone 이미 커밋 또는 롤백된 트랜잭션
+var errTxDone = errors.New("transaction has already been committed or rolled back")
+
+// errNestedTx 컨텍스트에 이미 진행 중인 트랜잭션이 있음 (같은 고루틴에서 Begin 을 중첩하면 writeMu 에서 멈추므로 거절)
+var errNestedTx = errors.New("transaction already in progress in context")
+
+// MemoryDB 모든 데이터를 프로세스 메모리에 보관하는 저장소 (테스트, 로컬 실행용)
+// 스토어들이 하나의 MemoryDB 를 공유하며, 트랜잭션은 한 번에 하나씩 실행되고(serializable)
+// Begin 에서 한 번 복사한 상태에만 기록하므로 롤백하면 변경이 사라지고 다른 요청에는 보이지 않음
+// 트랜잭션 밖의 읽기는 커밋된 상태를 보므로 열린 트랜잭션을 기다리지 않음
+type MemoryDB struct {
+	writeMu   sync.Mutex   // 트랜잭션과 트랜잭션 밖의 쓰기를 직렬화
+	mu        sync.RWMutex // committed 교체/조회 보호
+	committed *state
+}
+
+// state 저장소 전체 데이터
+type state struct {
+	events       map[string][]domain.Event
+	log          []domain.Event   // 모든 이벤트를 position 순서대로 (position 은 인덱스 + 1)
+	ids          map[string]int64 // 이벤트 ID -> position (중복 확인용)
+	accounts     map[string]domain.Account
+	snapshots    map[string]domain.Snapshot
+	outbox       []domain.OutboxMessage
+	nextOutboxID int64
+	inbox        map[inboxKey]domain.ProcessedEvent
+	checkpoints  map[string]domain.ProjectionCheckpoint
+	summaries    map[string]domain.AccountSummary
+}
+
+// inboxKey 인박스 기본키 (handler_name, event_id)
+type inboxKey struct {
+	handlerName string
+	eventID     string
+}
+
+// memoryTx 컨텍스트에 담기는 트랜잭션, 커밋 시 state 가 committed 를 대체
+type memoryTx struct {
+	mu    sync.Mutex
+	state *state
+	done  bool
+}
+
+// NewMemoryDB 빈 메모리 저장소 생성
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{
+		committed: &state{
+			events:      make(map[string][]domain.Event),
+			ids:         make(map[string]int64),
+			accounts:    make(map[string]domain.Account),
+			snapshots:   make(map[string]domain.Snapshot),
+			inbox:       make(map[inboxKey]domain.ProcessedEvent),
+			checkpoints: make(map[string]domain.ProjectionCheckpoint),
+			summaries:   make(map[string]domain.AccountSummary),
+		},
+	}
+}
+
+// clone 맵은 얕게 복사, 슬라이스는 쓰는 쪽에서 새로 할당하므로 공유해도 안전
+func (s *state) clone() *state {
+	return &state{
+		events:       maps.Clone(s.events),
+		log:          s.log,
+		ids:          maps.Clone(s.ids),
+		accounts:     maps.Clone(s.accounts),
+		snapshots:    maps.Clone(s.snapshots),
+		outbox:       s.outbox,
+		nextOutboxID: s.nextOutboxID,
+		inbox:        maps.Clone(s.inbox),
+		checkpoints:  maps.Clone(s.checkpoints),
+		summaries:    maps.Clone(s.summaries),
+	}
+}
+
+// read 컨텍스트의 트랜잭션 상태나 커밋된 상태를 읽음
+func (m *MemoryDB) read(ctx context.Context, fn func(s *state) error) error {
+	if tx, ok := ctx.Value(domain.TxKey).(*memoryTx); ok {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		if tx.done {
+			return errTxDone
+		}
+		return fn(tx.state)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return fn(m.committed)
+}
+
+// write 트랜잭션 안이면 트랜잭션 상태를 바로 고치고, 밖이면 단독 트랜잭션으로 실행
+// 상태를 복사하지 않으므로 fn 은 검증을 모두 마친 뒤에만 state 를 바꿔야 함 (에러를 반환할 때는 바꾸지 않음)
+func (m *MemoryDB) write(ctx context.Context, fn func(s *state) error) error {
+	if tx, ok := ctx.Value(domain.TxKey).(*memoryTx); ok {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		if tx.done {
+			return errTxDone
+		}
+		return fn(tx.state)
+	}
+
+	return m.RunInTransaction(ctx, func(tctx context.Context) error {
+		return m.write(tctx, fn)
+	})
+}
+
+// domain.TransactionManager 인터페이스 구현
+// 다른 트랜잭션이 끝날 때까지 대기, 컨텍스트에 이미 트랜잭션이 있으면 errNestedTx
+// 같은 고루틴에서 트랜잭션 컨텍스트가 아닌 ctx 로 쓰면 이 트랜잭션이 끝나기를 기다리며 멈춤
+func (m *MemoryDB) Begin(ctx context.Context) (context.Context, error) {
+	if _, ok := ctx.Value(domain.TxKey).(*memoryTx); ok {
+		return ctx, fmt.Errorf("failed to begin transaction: %w", errNestedTx)
+	}
+	m.writeMu.Lock()
+
+	m.mu.RLock()
+	tx := &memoryTx{state: m.committed.clone()}
+	m.mu.RUnlock()
+
+	return context.WithValue(ctx, domain.TxKey, tx), nil
+}
+
+func (m *MemoryDB) Commit(ctx context.Context) error {
+	tx, ok := ctx.Value(domain.TxKey).(*memoryTx)
+	if !ok {
+		return fmt.Errorf("no transaction found in context")
+	}
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return fmt.Errorf("failed to commit transaction: %w", errTxDone)
+	}
+
+	m.mu.Lock()
+	m.committed = tx.state
+	m.mu.Unlock()
+
+	tx.done = true
+	m.writeMu.Unlock()
+	return nil
+}
+
+func (m *MemoryDB) Rollback(ctx context.Context) error {
+	tx, ok := ctx.Value(domain.TxKey).(*memoryTx)
+	if !ok {
+		return fmt.Errorf("no transaction found in context")
+	}
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return fmt.Errorf("failed to rollback transaction: %w", errTxDone)
+	}
+
+	tx.state = nil
+	tx.done = true
+	m.writeMu.Unlock()
+	return nil
+}
+
+// domain.UnitOfWork 인터페이스 구현
+func (m *MemoryDB) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 이미 트랜잭션 안이면 세이브포인트처럼 실행, 실패하면 fn 의 변경만 되돌리고 커밋은 바깥 트랜잭션에 맡김
+	if tx, ok := ctx.Value(domain.TxKey).(*memoryTx); ok {
+		return tx.nested(ctx, fn)
+	}
+
+	newCtx, err := m.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.Rollback(newCtx)
+			panic(r) // re-throw panic after rollback
+		}
+	}()
+
+	if err := fn(newCtx); err != nil {
+		if rbErr := m.Rollback(newCtx); rbErr != nil {
+			return fmt.Errorf("rollback failed: %v (original error: %v)", rbErr, err)
+		}
+		return err
+	}
+
+	return m.Commit(newCtx)
+}
+
+func (m *MemoryDB) GetTransactionContext(ctx context.Context) context.Context {
+	return ctx
+}
+
+// nested 트랜잭션 상태를 복사해두고 fn 을 실행, 에러나 panic 이면 복사본으로 되돌림
+func (tx *memoryTx) nested(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx.mu.Lock()
+	if tx.done {
+		tx.mu.Unlock()
+		return errTxDone
+	}
+	savepoint := tx.state.clone()
+	tx.mu.Unlock()
+
+	restore := func() {
+		tx.mu.Lock()
+		defer tx.mu.Unlock()
+		if !tx.done {
+			tx.state = savepoint
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			restore()
+			panic(r) // re-throw panic after rollback
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
+
+// Close 메모리 저장소는 닫을 자원이 없음
+func (m *MemoryDB) Close() error {
+	return nil
+}
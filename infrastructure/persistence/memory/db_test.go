@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryDBTransaction(t *testing.T) {
+	ctx := context.Background()
+	account := func(id string) *domain.Account {
+		return &domain.Account{ID: id, UserName: "kim", Balance: 100, CreatedAt: time.Now()}
+	}
+
+	t.Run("커밋 전 변경은 트랜잭션 밖에서 보이지 않음", func(t *testing.T) {
+		db := NewMemoryDB()
+		accounts := NewAccountStore(db)
+
+		tctx, err := db.Begin(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, accounts.Create(tctx, account("account-1")))
+
+		_, err = accounts.FindByID(tctx, "account-1")
+		assert.NoError(t, err)
+		_, err = accounts.FindByID(ctx, "account-1")
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+
+		assert.NoError(t, db.Commit(tctx))
+		_, err = accounts.FindByID(ctx, "account-1")
+		assert.NoError(t, err)
+
+		// 커밋 후 롤백은 에러만 반환하고 아무것도 되돌리지 않음
+		assert.Error(t, db.Rollback(tctx))
+		_, err = accounts.FindByID(ctx, "account-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("롤백하면 모든 스토어의 변경이 사라짐", func(t *testing.T) {
+		db := NewMemoryDB()
+		accounts := NewAccountStore(db)
+		events := NewEventStore(db)
+		outbox := NewOutboxStore(db)
+
+		event, _ := domain.NewEvent("account-1", domain.AccountCreatedData{AccountID: "account-1", UserName: "kim"})
+		cause := errors.New("failed")
+		err := db.RunInTransaction(ctx, func(tctx context.Context) error {
+			assert.NoError(t, events.Save(tctx, "account-1", 0, []domain.Event{event}))
+			assert.NoError(t, accounts.Create(tctx, account("account-1")))
+			assert.NoError(t, outbox.Add(tctx, []domain.Event{event}))
+			return cause
+		})
+		assert.ErrorIs(t, err, cause)
+
+		stored, err := events.Load(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Empty(t, stored)
+		_, err = accounts.FindByID(ctx, "account-1")
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+		pending, err := outbox.FetchPending(ctx, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+		assert.NotContains(t, db.committed.ids, event.ID)
+
+		// 롤백된 이벤트 ID 는 다시 저장할 수 있고, 저장된 뒤에는 중복으로 거절
+		other, _ := domain.NewEvent("account-2", domain.AccountCreatedData{AccountID: "account-2", UserName: "lee"})
+		assert.NoError(t, events.Save(ctx, "account-2", 0, []domain.Event{other}))
+		assert.NoError(t, events.Save(ctx, "account-1", 0, []domain.Event{event}))
+		assert.ErrorIs(t, events.Save(ctx, "account-3", 0, []domain.Event{event}), domain.ErrDuplicateEventID)
+	})
+
+	t.Run("실패한 쓰기는 트랜잭션 상태를 바꾸지 않음", func(t *testing.T) {
+		db := NewMemoryDB()
+		accounts := NewAccountStore(db)
+		assert.NoError(t, accounts.Create(ctx, account("account-1")))
+
+		err := db.RunInTransaction(ctx, func(tctx context.Context) error {
+			assert.ErrorIs(t, accounts.Create(tctx, account("account-1")), domain.ErrAccountAlreadyExists)
+			assert.ErrorIs(t, accounts.Update(tctx, account("account-2")), domain.ErrAccountNotFound)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		list, err := accounts.ListAll(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+	})
+
+	t.Run("트랜잭션은 하나씩 실행", func(t *testing.T) {
+		db := NewMemoryDB()
+		accounts := NewAccountStore(db)
+
+		tctx, err := db.Begin(ctx)
+		assert.NoError(t, err)
+
+		written := make(chan error)
+		go func() {
+			written <- accounts.Create(ctx, account("account-2"))
+		}()
+
+		select {
+		case <-written:
+			t.Fatal("write outside transaction must wait for the open transaction")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		assert.NoError(t, accounts.Create(tctx, account("account-1")))
+		assert.NoError(t, db.Commit(tctx))
+		assert.NoError(t, <-written)
+
+		list, err := accounts.ListAll(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+	})
+	t.Run("트랜잭션 안에서 다시 시작하면 멈추지 않고 중첩 실행", func(t *testing.T) {
+		db := NewMemoryDB()
+		accounts := NewAccountStore(db)
+
+		tctx, err := db.Begin(ctx)
+		assert.NoError(t, err)
+		_, err = db.Begin(tctx)
+		assert.ErrorIs(t, err, errNestedTx)
+
+		// 중첩된 RunInTransaction 이 실패하면 그 안의 변경만 되돌림
+		assert.NoError(t, accounts.Create(tctx, account("account-1")))
+		cause := errors.New("failed")
+		err = db.RunInTransaction(tctx, func(nctx context.Context) error {
+			assert.NoError(t, accounts.Create(nctx, account("account-2")))
+			return cause
+		})
+		assert.ErrorIs(t, err, cause)
+		assert.NoError(t, db.RunInTransaction(tctx, func(nctx context.Context) error {
+			return accounts.Create(nctx, account("account-3"))
+		}))
+		assert.NoError(t, db.Commit(tctx))
+
+		list, err := accounts.ListAll(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		_, err = accounts.FindByID(ctx, "account-2")
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
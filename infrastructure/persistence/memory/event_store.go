@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+)
+
+type EventStore struct {
+	db *MemoryDB
+}
+
+func NewEventStore(db *MemoryDB) *EventStore {
+	return &EventStore{
+		db: db,
+	}
+}
+
+// Save 이벤트들을 expectedVersion 다음 버전부터 순서대로 저장하고 발급된 Version, Position 을 events 에 채움
+// 스트림의 현재 버전이 expectedVersion 과 다르면 ErrConcurrencyConflict 반환
+func (r *EventStore) Save(ctx context.Context, accountId string, expectedVersion int64, events []domain.Event) error {
+	if err := domain.ValidateNewEvents(events); err != nil {
+		return fmt.Errorf("failed to save events of %s: %w", accountId, err)
+	}
+
+	return r.db.write(ctx, func(s *state) error {
+		stream := s.events[accountId]
+		currentVersion := int64(len(stream))
+		if currentVersion != expectedVersion {
+			return fmt.Errorf("%w: expected version %d, current version %d",
+				domain.ErrConcurrencyConflict, expectedVersion, currentVersion)
+		}
+		if err := checkExistingIDs(s.ids, events); err != nil {
+			return err
+		}
+
+		// 커밋된 상태와 배열을 공유하지 않도록 새로 할당해서 추가
+		stream = stream[:len(stream):len(stream)]
+		log := s.log[:len(s.log):len(s.log)]
+		for i := range events {
+			events[i].Version = expectedVersion + int64(i) + 1
+			events[i].Position = int64(len(log)) + 1
+			stream = append(stream, events[i])
+			log = append(log, events[i])
+			s.ids[events[i].ID] = events[i].Position
+		}
+		s.events[accountId] = stream
+		s.log = log
+		return nil
+	})
+}
+
+// checkExistingIDs events 중 이미 저장된 ID 가 있으면 *domain.EventError 반환
+func checkExistingIDs(ids map[string]int64, events []domain.Event) error {
+	for i, event := range events {
+		if _, exists := ids[event.ID]; exists {
+			return &domain.EventError{Index: i, EventID: event.ID, EventType: event.EventType, Err: domain.ErrDuplicateEventID}
+		}
+	}
+	return nil
+}
+
+// Load 특정 계좌의 모든 이벤트를 버전 순서대로 조회
+func (r *EventStore) Load(ctx context.Context, accountId string) ([]domain.Event, error) {
+	return r.LoadAfter(ctx, accountId, 0)
+}
+
+// LoadAfter afterVersion 이후의 이벤트만 버전 순서대로 조회
+func (r *EventStore) LoadAfter(ctx context.Context, accountId string, afterVersion int64) ([]domain.Event, error) {
+	var events []domain.Event
+	err := r.db.read(ctx, func(s *state) error {
+		stream := s.events[accountId]
+		if afterVersion < 0 {
+			afterVersion = 0
+		}
+		if afterVersion >= int64(len(stream)) {
+			events = []domain.Event{}
+			return nil
+		}
+		events = append([]domain.Event{}, stream[afterVersion:]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain.UpcastEvents(events)
+}
+
+// ReadAll fromPosition 이후의 이벤트를 계좌와 상관없이 position 순서대로 조회
+func (r *EventStore) ReadAll(ctx context.Context, fromPosition int64, limit int) ([]domain.Event, error) {
+	var events []domain.Event
+	err := r.db.read(ctx, func(s *state) error {
+		if fromPosition < 0 {
+			fromPosition = 0
+		}
+		if fromPosition >= int64(len(s.log)) || limit <= 0 {
+			events = []domain.Event{}
+			return nil
+		}
+		end := min(int64(len(s.log)), fromPosition+int64(limit))
+		events = append([]domain.Event{}, s.log[fromPosition:end]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain.UpcastEvents(events)
+}
+
+// LoadRange 계좌 스트림에서 조건에 맞는 이벤트를 버전 순서대로 조회
+func (r *EventStore) LoadRange(ctx context.Context, accountId string, query domain.EventQuery) ([]domain.Event, error) {
+	events := []domain.Event{}
+	err := r.db.read(ctx, func(s *state) error {
+		for _, event := range s.events[accountId] {
+			if query.Limit > 0 && len(events) >= query.Limit {
+				break
+			}
+			if query.Matches(event) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain.UpcastEvents(events)
+}
+
+// Stream 조건에 맞는 이벤트를 domain.StreamPageSize 개씩 나눠 읽는 반복자
+func (r *EventStore) Stream(ctx context.Context, accountId string, query domain.EventQuery) domain.EventIterator {
+	return domain.NewPagedEventIterator(ctx, r.LoadRange, accountId, query)
+}
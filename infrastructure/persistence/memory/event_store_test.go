@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/eventstoretest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventStore(NewMemoryDB())
+
+	deposit := func(amount int64) domain.Event {
+		event, err := domain.NewEvent("account-1", domain.MoneyDepositedData{AccountID: "account-1", Amount: amount})
+		assert.NoError(t, err)
+		return event
+	}
+
+	assert.NoError(t, store.Save(ctx, "account-1", 0, []domain.Event{deposit(10), deposit(20)}))
+	assert.NoError(t, store.Save(ctx, "account-1", 2, []domain.Event{deposit(30)}))
+
+	t.Run("버전 충돌", func(t *testing.T) {
+		err := store.Save(ctx, "account-1", 1, []domain.Event{deposit(40)})
+		assert.ErrorIs(t, err, domain.ErrConcurrencyConflict)
+	})
+
+	t.Run("ID 없는 이벤트", func(t *testing.T) {
+		err := store.Save(ctx, "account-1", 3, []domain.Event{{EventType: string(domain.MoneyDeposited)}})
+		assert.ErrorIs(t, err, domain.ErrMissingEventID)
+	})
+
+	t.Run("버전 순서대로 조회", func(t *testing.T) {
+		events, err := store.Load(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Len(t, events, 3)
+		for i, event := range events {
+			assert.Equal(t, int64(i+1), event.Version)
+		}
+
+		events, err = store.LoadAfter(ctx, "account-1", 2)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		data, err := events[0].DecodeData()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30), data.(domain.MoneyDepositedData).Amount)
+
+		events, err = store.Load(ctx, "account-2")
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("조회 결과를 수정해도 저장된 이벤트는 그대로", func(t *testing.T) {
+		events, _ := store.Load(ctx, "account-1")
+		events[0].AccountID = "changed"
+
+		events, _ = store.Load(ctx, "account-1")
+		assert.Equal(t, "account-1", events[0].AccountID)
+	})
+}
+
+func TestEventStoreConformance(t *testing.T) {
+	eventstoretest.Run(t, func(t *testing.T) eventstoretest.Fixture {
+		db := NewMemoryDB()
+		return eventstoretest.Fixture{Store: NewEventStore(db), TxManager: db}
+	})
+}
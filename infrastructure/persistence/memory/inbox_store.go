@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+	"time"
+)
+
+type InboxStore struct {
+	db *MemoryDB
+}
+
+func NewInboxStore(db *MemoryDB) *InboxStore {
+	return &InboxStore{
+		db: db,
+	}
+}
+
+// Record (handler_name, event_id) 가 처음 기록될 때만 true 반환
+func (r *InboxStore) Record(ctx context.Context, handlerName string, eventID string) (bool, error) {
+	recorded := false
+	err := r.db.write(ctx, func(s *state) error {
+		key := inboxKey{handlerName: handlerName, eventID: eventID}
+		if _, exists := s.inbox[key]; exists {
+			return nil
+		}
+		s.inbox[key] = domain.ProcessedEvent{
+			HandlerName: handlerName,
+			EventID:     eventID,
+			ProcessedAt: time.Now(),
+		}
+		recorded = true
+		return nil
+	})
+	return recorded, err
+}
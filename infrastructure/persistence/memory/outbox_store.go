@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"time"
+)
+
+type OutboxStore struct {
+	db *MemoryDB
+}
+
+func NewOutboxStore(db *MemoryDB) *OutboxStore {
+	return &OutboxStore{
+		db: db,
+	}
+}
+
+// Add 이벤트들을 outbox 에 기록 (커맨드와 같은 트랜잭션)
+func (r *OutboxStore) Add(ctx context.Context, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]domain.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		if event.ID == "" {
+			return fmt.Errorf("%w: %s event of %s", domain.ErrMissingEventID, event.EventType, event.AccountID)
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %v", err)
+		}
+		messages = append(messages, domain.OutboxMessage{
+			EventID:   event.ID,
+			AccountID: event.AccountID,
+			Payload:   payload,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	return r.db.write(ctx, func(s *state) error {
+		outbox := s.outbox[:len(s.outbox):len(s.outbox)]
+		for _, message := range messages {
+			s.nextOutboxID++
+			message.ID = s.nextOutboxID
+			outbox = append(outbox, message)
+		}
+		s.outbox = outbox
+		return nil
+	})
+}
+
+// FetchPending 미발행 메시지를 기록된 순서대로 조회
+// 트랜잭션 안에서 호출하면 트랜잭션이 끝날 때까지 다른 릴레이가 같은 메시지를 가져가지 않음
+func (r *OutboxStore) FetchPending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	var messages []domain.OutboxMessage
+	err := r.db.read(ctx, func(s *state) error {
+		for _, message := range s.outbox {
+			if len(messages) >= limit {
+				break
+			}
+			if message.SentAt == nil {
+				messages = append(messages, message)
+			}
+		}
+		return nil
+	})
+	return messages, err
+}
+
+// MarkSent 발행 완료 시각 기록
+func (r *OutboxStore) MarkSent(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sent := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		sent[id] = true
+	}
+	now := time.Now()
+
+	return r.db.write(ctx, func(s *state) error {
+		outbox := append([]domain.OutboxMessage{}, s.outbox...)
+		for i := range outbox {
+			if sent[outbox[i].ID] {
+				outbox[i].SentAt = &now
+			}
+		}
+		s.outbox = outbox
+		return nil
+	})
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"go-eventsourcing-patterns/domain"
+)
+
+type SnapshotStore struct {
+	db *MemoryDB
+}
+
+func NewSnapshotStore(db *MemoryDB) *SnapshotStore {
+	return &SnapshotStore{
+		db: db,
+	}
+}
+
+// Save 계좌별 스냅샷 저장 (더 오래된 버전으로 덮어쓰지 않음)
+func (r *SnapshotStore) Save(ctx context.Context, snapshot domain.Snapshot) error {
+	return r.db.write(ctx, func(s *state) error {
+		if current, exists := s.snapshots[snapshot.AccountID]; exists && current.Version >= snapshot.Version {
+			return nil
+		}
+		s.snapshots[snapshot.AccountID] = snapshot
+		return nil
+	})
+}
+
+// Load 계좌의 최신 스냅샷 조회, 없으면 nil
+func (r *SnapshotStore) Load(ctx context.Context, accountID string) (*domain.Snapshot, error) {
+	var snapshot *domain.Snapshot
+	err := r.db.read(ctx, func(s *state) error {
+		if found, exists := s.snapshots[accountID]; exists {
+			snapshot = &found
+		}
+		return nil
+	})
+	return snapshot, err
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	accountSummariesTable       = "account_summaries"
+	accountSummariesShadowTable = "account_summaries_shadow"
+)
+
+type AccountSummaryStore struct {
+	db    *PostgresDB
+	table string
+}
+
+func NewAccountSummaryStore(db *PostgresDB) *AccountSummaryStore {
+	return &AccountSummaryStore{
+		db:    db,
+		table: accountSummariesTable,
+	}
+}
+
+func (r *AccountSummaryStore) conn(ctx context.Context) *gorm.DB {
+	return r.db.conn(ctx).Table(r.table)
+}
+
+// FindByID 계좌 요약 조회, 없으면 ErrAccountNotFound
+func (r *AccountSummaryStore) FindByID(ctx context.Context, accountID string) (*domain.AccountSummary, error) {
+	var summary domain.AccountSummary
+	err := r.conn(ctx).Where("account_id = ?", accountID).First(&summary).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAccountNotFound, accountID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account summary of %s: %v", accountID, err)
+	}
+	return &summary, nil
+}
+
+// ListAll 모든 계좌 요약을 생성 순서대로 조회
+func (r *AccountSummaryStore) ListAll(ctx context.Context) ([]domain.AccountSummary, error) {
+	var summaries []domain.AccountSummary
+	if err := r.conn(ctx).Order("created_at, account_id").Find(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list account summaries: %v", err)
+	}
+	return summaries, nil
+}
+
+// Save 계좌 요약이 없으면 만들고 있으면 덮어씀
+func (r *AccountSummaryStore) Save(ctx context.Context, summary *domain.AccountSummary) error {
+	if err := r.conn(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(summary).Error; err != nil {
+		return fmt.Errorf("failed to save account summary of %s: %v", summary.AccountID, err)
+	}
+	return nil
+}
+
+// Delete 계좌 요약 삭제
+func (r *AccountSummaryStore) Delete(ctx context.Context, accountIDs []string) error {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+	if err := r.conn(ctx).Where("account_id IN ?", accountIDs).Delete(&domain.AccountSummary{}).Error; err != nil {
+		return fmt.Errorf("failed to delete account summaries from %s: %v", r.table, err)
+	}
+	return nil
+}
+
+// Reset 모든 계좌 요약 삭제
+func (r *AccountSummaryStore) Reset(ctx context.Context) error {
+	if err := r.db.conn(ctx).Exec("TRUNCATE TABLE " + r.table).Error; err != nil {
+		return fmt.Errorf("failed to reset %s: %v", r.table, err)
+	}
+	return nil
+}
+
+// CreateShadow account_summaries 와 같은 구조의 빈 임시 테이블을 새로 만듦
+func (r *AccountSummaryStore) CreateShadow(ctx context.Context) (domain.AccountSummaryStore, error) {
+	conn := r.db.conn(ctx)
+	if err := conn.Exec("DROP TABLE IF EXISTS " + accountSummariesShadowTable).Error; err != nil {
+		return nil, fmt.Errorf("failed to drop shadow table: %v", err)
+	}
+	if err := conn.Exec(fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)",
+		accountSummariesShadowTable, accountSummariesTable)).Error; err != nil {
+		return nil, fmt.Errorf("failed to create shadow table: %v", err)
+	}
+	return &AccountSummaryStore{db: r.db, table: accountSummariesShadowTable}, nil
+}
+
+// PromoteShadow 기존 테이블을 지우고 임시 테이블의 이름을 바꿈
+// 트랜잭션이 커밋되기 전까지는 기존 테이블이 그대로 보이고, 조회는 교체가 끝날 때까지 잠깐 대기함
+func (r *AccountSummaryStore) PromoteShadow(ctx context.Context) error {
+	conn := r.db.conn(ctx)
+	if err := conn.Exec("DROP TABLE " + accountSummariesTable).Error; err != nil {
+		return fmt.Errorf("failed to drop %s: %v", accountSummariesTable, err)
+	}
+	if err := conn.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s",
+		accountSummariesShadowTable, accountSummariesTable)).Error; err != nil {
+		return fmt.Errorf("failed to promote shadow table: %v", err)
+	}
+	return nil
+}
@@ -25,7 +25,8 @@ func NewPostgresDB(config *domain.Config) (*PostgresDB, error) {
 		config.SSLMode,
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// TranslateError: 유니크 제약 위반을 gorm.ErrDuplicatedKey 로 변환 (낙관적 동시성 제어에 사용)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -38,6 +39,14 @@ func (p *PostgresDB) GetDB() *gorm.DB {
 	return p.db
 }
 
+// conn 컨텍스트에 트랜잭션이 있으면 해당 트랜잭션을, 없으면 기본 연결을 반환
+func (p *PostgresDB) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(domain.TxKey).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return p.db.WithContext(ctx)
+}
+
 // domain.TransactionManager 인터페이스 구현
 func (p *PostgresDB) Begin(ctx context.Context) (context.Context, error) {
 	tx := p.db.Begin()
@@ -71,6 +80,13 @@ func (p *PostgresDB) Rollback(ctx context.Context) error {
 
 // domain.UnitOfWork 인터페이스 구현
 func (p *PostgresDB) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 이미 트랜잭션 안이면 세이브포인트로 중첩해서 실패한 부분만 되돌리고 커밋은 바깥 트랜잭션에 맡김
+	// (릴레이 트랜잭션 안에서 실행되는 동기 구독자가 새 커넥션을 기다리지 않도록)
+	if tx, ok := ctx.Value(domain.TxKey).(*gorm.DB); ok {
+		return tx.Transaction(func(nested *gorm.DB) error {
+			return fn(context.WithValue(ctx, domain.TxKey, nested))
+		})
+	}
 
 	newCtx, err := p.Begin(ctx)
 	if err != nil {
@@ -4,8 +4,19 @@ import (
 	"context"
 	"fmt"
 	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/gormstore"
+	"gorm.io/gorm"
 )
 
+// eventLogLockKey 이벤트 저장을 직렬화하는 트랜잭션 단위 advisory lock 키
+// position 은 INSERT 시점에 발급되므로 잠금 없이 동시에 저장하면 작은 position 이 나중에 커밋될 수 있고,
+// 그 사이에 ReadAll 로 따라 읽던 쪽은 그 이벤트를 영영 건너뛰게 됨
+const eventLogLockKey = 0x65766c6f67 // "evlog"
+
+// saveBatchSavepoint INSERT 가 실패했을 때 되돌릴 세이브포인트 이름
+// Postgres 는 실패한 트랜잭션에서 조회를 할 수 없으므로 되돌린 뒤에 어느 이벤트 때문인지 조회
+const saveBatchSavepoint = "save_events_batch"
+
 type EventStore struct {
 	db *PostgresDB
 }
@@ -16,27 +27,137 @@ func NewEventStore(db *PostgresDB) *EventStore {
 	}
 }
 
-// Save 이벤트들을 저장
-func (r *EventStore) Save(ctx context.Context, accountId string, events []domain.Event) error {
-	tx := r.db.db.WithContext(ctx)
-	for _, event := range events {
-		if err := tx.Create(&event).Error; err != nil {
-			return fmt.Errorf("failed to save AccountCreatedEvent: %v", err)
+// Save 이벤트들을 expectedVersion 다음 버전부터 순서대로 저장하고 발급된 Version, Position 을 events 에 채움
+// 동시에 같은 버전을 쓰려는 요청은 (account_id, version) 유니크 제약에 걸려 ErrConcurrencyConflict 로 변환됨
+// 컨텍스트에 트랜잭션이 있으면 세이브포인트로, 없으면 새 트랜잭션으로 실행
+func (r *EventStore) Save(ctx context.Context, accountId string, expectedVersion int64, events []domain.Event) error {
+	if err := domain.ValidateNewEvents(events); err != nil {
+		return fmt.Errorf("failed to save events of %s: %w", accountId, err)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.db.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// 커밋될 때까지 다른 저장을 막아서 position 이 커밋 순서대로 보이게 함
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", eventLogLockKey).Error; err != nil {
+			return fmt.Errorf("failed to lock event log: %v", err)
+		}
+		return r.save(tx, accountId, expectedVersion, events)
+	})
+}
+
+func (r *EventStore) save(tx *gorm.DB, accountId string, expectedVersion int64, events []domain.Event) error {
+	var currentVersion int64
+	if err := tx.Model(&domain.Event{}).
+		Where("account_id = ?", accountId).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&currentVersion).Error; err != nil {
+		return fmt.Errorf("failed to read stream version: %v", err)
+	}
+	if currentVersion != expectedVersion {
+		return fmt.Errorf("%w: expected version %d, current version %d",
+			domain.ErrConcurrencyConflict, expectedVersion, currentVersion)
+	}
+
+	for i := range events {
+		events[i].Version = expectedVersion + int64(i) + 1
+		events[i].Position = 0 // 저장소가 발급
+	}
+
+	// 이미 저장된 ID 가 있으면 어느 이벤트인지 알려주기 위해 INSERT 전에 확인
+	if err := gormstore.CheckExistingIDs(tx, events); err != nil {
+		return err
+	}
+
+	// SaveBatchSize 개씩 다중 행 INSERT, RETURNING 으로 발급된 position 이 events 에 채워짐
+	for from := 0; from < len(events); from += gormstore.SaveBatchSize {
+		to := min(from+gormstore.SaveBatchSize, len(events))
+		batch := events[from:to]
+		if err := tx.SavePoint(saveBatchSavepoint).Error; err != nil {
+			return fmt.Errorf("failed to create savepoint: %v", err)
+		}
+		if err := tx.Create(&batch).Error; err != nil {
+			return gormstore.InsertError(tx, saveBatchSavepoint, accountId, events, from, to, err)
 		}
 	}
 	return nil
 }
 
-// Load 특정 계좌의 모든 이벤트 조회
+// Load 특정 계좌의 모든 이벤트를 버전 순서대로 조회 (애그리거트 재구성용)
 func (r *EventStore) Load(ctx context.Context, accountId string) ([]domain.Event, error) {
 	var events []domain.Event
-	tx := r.db.db.WithContext(ctx).
+	tx := r.db.conn(ctx).
 		Where("account_id = ?", accountId).
-		Order("created_at desc").
+		Order("version asc").
+		Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	// 이전 스키마 버전으로 저장된 페이로드는 현재 버전으로 변환해서 반환
+	return domain.UpcastEvents(events)
+}
+
+// LoadAfter afterVersion 이후의 이벤트만 버전 순서대로 조회
+func (r *EventStore) LoadAfter(ctx context.Context, accountId string, afterVersion int64) ([]domain.Event, error) {
+	var events []domain.Event
+	tx := r.db.conn(ctx).
+		Where("account_id = ? AND version > ?", accountId, afterVersion).
+		Order("version asc").
+		Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return domain.UpcastEvents(events)
+}
+
+// ReadAll fromPosition 이후의 이벤트를 계좌와 상관없이 position 순서대로 조회
+func (r *EventStore) ReadAll(ctx context.Context, fromPosition int64, limit int) ([]domain.Event, error) {
+	var events []domain.Event
+	tx := r.db.conn(ctx).
+		Where("position > ?", fromPosition).
+		Order("position asc").
+		Limit(limit).
 		Find(&events)
 
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
-	return events, nil
+	return domain.UpcastEvents(events)
+}
+
+// LoadRange 계좌 스트림에서 조건에 맞는 이벤트를 버전 순서대로 조회
+func (r *EventStore) LoadRange(ctx context.Context, accountId string, query domain.EventQuery) ([]domain.Event, error) {
+	tx := r.db.conn(ctx).Where("account_id = ?", accountId)
+	if query.FromVersion > 0 {
+		tx = tx.Where("version >= ?", query.FromVersion)
+	}
+	if query.ToVersion > 0 {
+		tx = tx.Where("version <= ?", query.ToVersion)
+	}
+	if !query.FromTime.IsZero() {
+		tx = tx.Where("created_at >= ?", query.FromTime)
+	}
+	if !query.ToTime.IsZero() {
+		tx = tx.Where("created_at < ?", query.ToTime)
+	}
+	if len(query.EventTypes) > 0 {
+		tx = tx.Where("event_type IN ?", query.EventTypeNames())
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	var events []domain.Event
+	if err := tx.Order("version asc").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return domain.UpcastEvents(events)
+}
+
+// Stream 조건에 맞는 이벤트를 domain.StreamPageSize 개씩 나눠 읽는 반복자
+func (r *EventStore) Stream(ctx context.Context, accountId string, query domain.EventQuery) domain.EventIterator {
+	return domain.NewPagedEventIterator(ctx, r.LoadRange, accountId, query)
 }
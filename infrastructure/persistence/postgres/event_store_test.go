@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/eventstoretest"
+	"go-eventsourcing-patterns/infrastructure/persistence/gormstore"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+)
+
+// TestEventStoreConformance deployments/postgres/init.sql 로 스키마를 만든 데이터베이스가 필요
+// POSTGRES_TEST_DSN 이 없으면 건너뜀, make test-postgres 는 Postgres 컨테이너를 띄우고 DSN 을 넣어서 실행
+//
+//	POSTGRES_TEST_DSN="host=localhost user=user password=password dbname=eventstore port=5432 sslmode=disable" go test ./infrastructure/persistence/postgres/
+func TestEventStoreConformance(t *testing.T) {
+	if os.Getenv("POSTGRES_TEST_DSN") == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	eventstoretest.Run(t, func(t *testing.T) eventstoretest.Fixture {
+		db := openTestDB(t)
+		t.Cleanup(func() { db.Close() })
+		return eventstoretest.Fixture{Store: NewEventStore(db), TxManager: db}
+	})
+}
+
+// TestInsertError INSERT 가 유니크 제약에 걸리면 배치 안에서 원인이 된 이벤트를 알려줌
+func TestInsertError(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	eventstoretest.RunInsertErrors(t, NewEventStore(db), func(accountID string, events []domain.Event, from int, firstVersion int64) error {
+		return db.GetDB().Transaction(func(tx *gorm.DB) error {
+			batch := events[from:]
+			for i := range batch {
+				batch[i].Version = firstVersion + int64(i)
+				batch[i].Position = 0
+			}
+			if err := tx.SavePoint(saveBatchSavepoint).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&batch).Error; err != nil {
+				return gormstore.InsertError(tx, saveBatchSavepoint, accountID, events, from, len(events), err)
+			}
+			return nil
+		})
+	})
+}
+
+// BenchmarkEventStoreSave 다중 행 INSERT 로 저장하는 Save 와 이벤트마다 INSERT 하는 방식 비교
+// TestEventStoreConformance 와 같은 POSTGRES_TEST_DSN 데이터베이스 사용
+//
+//	go test -run '^$' -bench EventStoreSave ./infrastructure/persistence/postgres/
+func BenchmarkEventStoreSave(b *testing.B) {
+	db := openTestDB(b)
+	defer db.Close()
+
+	eventstoretest.BenchmarkSave(b, NewEventStore(db), func(accountID string, events []domain.Event) error {
+		return db.GetDB().Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", eventLogLockKey).Error; err != nil {
+				return err
+			}
+			for i := range events {
+				events[i].Version = int64(i) + 1
+				if err := tx.Create(&events[i]).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// openTestDB POSTGRES_TEST_DSN 데이터베이스에 연결, 없으면 건너뜀
+func openTestDB(tb testing.TB) *PostgresDB {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		tb.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	gdb, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		tb.Fatalf("failed to connect to postgres: %v", err)
+	}
+	return &PostgresDB{db: gdb}
+}
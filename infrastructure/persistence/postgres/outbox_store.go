@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type OutboxStore struct {
+	db *PostgresDB
+}
+
+func NewOutboxStore(db *PostgresDB) *OutboxStore {
+	return &OutboxStore{
+		db: db,
+	}
+}
+
+// Add 이벤트들을 outbox 테이블에 기록 (커맨드와 같은 트랜잭션)
+func (r *OutboxStore) Add(ctx context.Context, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]domain.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		if event.ID == "" {
+			return fmt.Errorf("%w: %s event of %s", domain.ErrMissingEventID, event.EventType, event.AccountID)
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %v", err)
+		}
+		messages = append(messages, domain.OutboxMessage{
+			EventID:   event.ID,
+			AccountID: event.AccountID,
+			Payload:   payload,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	if err := r.db.conn(ctx).Create(&messages).Error; err != nil {
+		return fmt.Errorf("failed to add outbox messages: %v", err)
+	}
+	return nil
+}
+
+// FetchPending 미발행 메시지를 id 순서대로 잠금과 함께 조회 (FOR UPDATE SKIP LOCKED)
+// 다른 릴레이가 잠근 메시지는 기다리지 않고 건너뛰고,
+// 건너뛴 메시지보다 뒤에 있는 같은 계좌의 메시지는 계좌별 발행 순서를 지키기 위해 이번 배치에서 뺌
+func (r *OutboxStore) FetchPending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	conn := r.db.conn(ctx)
+
+	var messages []domain.OutboxMessage
+	tx := conn.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("sent_at IS NULL").
+		Order("id asc").
+		Limit(limit).
+		Find(&messages)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	fetched := make(map[int64]bool, len(messages))
+	var accountIDs []string
+	for _, m := range messages {
+		fetched[m.ID] = true
+		accountIDs = append(accountIDs, m.AccountID)
+	}
+
+	// 잠금과 상관없이 보이는 같은 계좌의 미발행 메시지 중에서 가져오지 못한 것 (다른 릴레이가 잡고 있음)
+	var pending []domain.OutboxMessage
+	tx = conn.
+		Select("id", "account_id").
+		Where("sent_at IS NULL AND account_id IN ? AND id <= ?", accountIDs, messages[len(messages)-1].ID).
+		Order("id asc").
+		Find(&pending)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	blocked := make(map[string]int64)
+	for _, m := range pending {
+		if _, ok := blocked[m.AccountID]; !ok && !fetched[m.ID] {
+			blocked[m.AccountID] = m.ID
+		}
+	}
+
+	ordered := messages[:0]
+	for _, m := range messages {
+		if first, ok := blocked[m.AccountID]; ok && m.ID > first {
+			continue
+		}
+		ordered = append(ordered, m)
+	}
+	return ordered, nil
+}
+
+// MarkSent 발행 완료 시각 기록
+func (r *OutboxStore) MarkSent(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tx := r.db.conn(ctx).
+		Model(&domain.OutboxMessage{}).
+		Where("id IN ?", ids).
+		Update("sent_at", time.Now())
+	return tx.Error
+}
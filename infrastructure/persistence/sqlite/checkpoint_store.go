@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type CheckpointStore struct {
+	db *SQLiteDB
+}
+
+func NewCheckpointStore(db *SQLiteDB) *CheckpointStore {
+	return &CheckpointStore{
+		db: db,
+	}
+}
+
+// Load 체크포인트 position 조회, 기록이 없으면 0
+func (r *CheckpointStore) Load(ctx context.Context, projectionName string) (int64, error) {
+	var checkpoint domain.ProjectionCheckpoint
+	err := r.db.conn(ctx).Where("projection_name = ?", projectionName).First(&checkpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load checkpoint of %s: %v", projectionName, err)
+	}
+	return checkpoint.Position, nil
+}
+
+// Save 체크포인트가 없으면 만들고 있으면 덮어씀
+func (r *CheckpointStore) Save(ctx context.Context, projectionName string, position int64) error {
+	err := r.db.conn(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&domain.ProjectionCheckpoint{
+			ProjectionName: projectionName,
+			Position:       position,
+			UpdatedAt:      time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint of %s: %v", projectionName, err)
+	}
+	return nil
+}
+
+// List 모든 체크포인트를 이름 순서대로 조회
+func (r *CheckpointStore) List(ctx context.Context) ([]domain.ProjectionCheckpoint, error) {
+	var checkpoints []domain.ProjectionCheckpoint
+	if err := r.db.conn(ctx).Order("projection_name").Find(&checkpoints).Error; err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %v", err)
+	}
+	return checkpoints, nil
+}
+
+// Delete 체크포인트 삭제, 기록이 없어도 에러 아님
+func (r *CheckpointStore) Delete(ctx context.Context, projectionName string) error {
+	err := r.db.conn(ctx).Where("projection_name = ?", projectionName).Delete(&domain.ProjectionCheckpoint{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete checkpoint of %s: %v", projectionName, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/glebarez/sqlite"
+	"go-eventsourcing-patterns/domain"
+	"gorm.io/gorm"
+	"strings"
+)
+
+//go:embed schema.sql
+var schema string
+
+// SQLiteDB는 SQLite 파일 데이터베이스 연결과 트랜잭션을 관리하는 구조체 (Postgres 없는 환경용)
+type SQLiteDB struct {
+	db *gorm.DB
+}
+
+// NewSQLiteDB path 의 데이터베이스를 열고 스키마가 없으면 생성, ":memory:" 면 메모리 데이터베이스
+// SQLite 는 쓰기가 하나씩만 가능하므로 연결을 하나만 사용해서 트랜잭션을 직렬화
+func NewSQLiteDB(path string) (*SQLiteDB, error) {
+	dsn := path
+	if !strings.Contains(dsn, "?") {
+		dsn += "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	}
+
+	// TranslateError: 유니크 제약 위반을 gorm.ErrDuplicatedKey 로 변환 (낙관적 동시성 제어에 사용)
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.Exec(schema).Error; err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return &SQLiteDB{db: db}, nil
+}
+
+// GetDB는 gorm.DB 인스턴스를 반환합니다
+func (p *SQLiteDB) GetDB() *gorm.DB {
+	return p.db
+}
+
+// conn 컨텍스트에 트랜잭션이 있으면 해당 트랜잭션을, 없으면 기본 연결을 반환
+func (p *SQLiteDB) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(domain.TxKey).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return p.db.WithContext(ctx)
+}
+
+// domain.TransactionManager 인터페이스 구현
+func (p *SQLiteDB) Begin(ctx context.Context) (context.Context, error) {
+	tx := p.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return ctx, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+	return context.WithValue(ctx, domain.TxKey, tx), nil
+}
+
+func (p *SQLiteDB) Commit(ctx context.Context) error {
+	tx, ok := ctx.Value(domain.TxKey).(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("no transaction found in context")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (p *SQLiteDB) Rollback(ctx context.Context) error {
+	tx, ok := ctx.Value(domain.TxKey).(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("no transaction found in context")
+	}
+	if err := tx.Rollback().Error; err != nil {
+		return fmt.Errorf("failed to rollback transaction: %w", err)
+	}
+	return nil
+}
+
+// domain.UnitOfWork 인터페이스 구현
+func (p *SQLiteDB) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 이미 트랜잭션 안이면 세이브포인트로 중첩해서 실패한 부분만 되돌리고 커밋은 바깥 트랜잭션에 맡김
+	// (릴레이 트랜잭션 안에서 실행되는 동기 구독자가 새 커넥션을 기다리지 않도록)
+	if tx, ok := ctx.Value(domain.TxKey).(*gorm.DB); ok {
+		return tx.Transaction(func(nested *gorm.DB) error {
+			return fn(context.WithValue(ctx, domain.TxKey, nested))
+		})
+	}
+
+	newCtx, err := p.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.Rollback(newCtx)
+			panic(r) // re-throw panic after rollback
+		}
+	}()
+
+	if err := fn(newCtx); err != nil {
+		if rbErr := p.Rollback(newCtx); rbErr != nil {
+			return fmt.Errorf("rollback failed: %v (original error: %v)", rbErr, err)
+		}
+		return err
+	}
+
+	return p.Commit(newCtx)
+}
+
+func (p *SQLiteDB) GetTransactionContext(ctx context.Context) context.Context {
+	tx, ok := ctx.Value(domain.TxKey).(*gorm.DB)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, domain.TxKey, tx)
+}
+
+// Close closes the database connection
+func (p *SQLiteDB) Close() error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("failed to close database connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteDB(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "eventstore.db")
+
+	db, err := NewSQLiteDB(path)
+	assert.NoError(t, err)
+
+	accounts := NewAccountStore(db)
+	events := NewEventStore(db)
+	outbox := NewOutboxStore(db)
+	snapshots := NewSnapshotStore(db)
+
+	created, _ := domain.NewEvent("account-1", domain.AccountCreatedData{AccountID: "account-1", UserName: "kim", InitialBalance: 100})
+
+	t.Run("롤백하면 변경이 사라짐", func(t *testing.T) {
+		cause := errors.New("failed")
+		err := db.RunInTransaction(ctx, func(tctx context.Context) error {
+			assert.NoError(t, events.Save(tctx, "account-1", 0, []domain.Event{created}))
+			assert.NoError(t, outbox.Add(tctx, []domain.Event{created}))
+			return cause
+		})
+		assert.ErrorIs(t, err, cause)
+
+		stored, err := events.Load(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Empty(t, stored)
+		pending, err := outbox.FetchPending(ctx, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("커밋과 동시성 충돌", func(t *testing.T) {
+		now := time.Now()
+		err := db.RunInTransaction(ctx, func(tctx context.Context) error {
+			if err := events.Save(tctx, "account-1", 0, []domain.Event{created}); err != nil {
+				return err
+			}
+			return accounts.Create(tctx, &domain.Account{ID: "account-1", UserName: "kim", Balance: 100, CreatedAt: now, UpdatedAt: now})
+		})
+		assert.NoError(t, err)
+
+		deposit, _ := domain.NewEvent("account-1", domain.MoneyDepositedData{AccountID: "account-1", Amount: 10})
+		assert.ErrorIs(t, events.Save(ctx, "account-1", 0, []domain.Event{deposit}), domain.ErrConcurrencyConflict)
+		assert.NoError(t, events.Save(ctx, "account-1", 1, []domain.Event{deposit}))
+
+		stored, err := events.Load(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Len(t, stored, 2)
+		assert.Equal(t, int64(2), stored[1].Version)
+
+		account, err := accounts.FindByID(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), account.Balance)
+		assert.True(t, account.CreatedAt.Equal(now))
+	})
+
+	t.Run("스냅샷은 더 오래된 버전으로 덮어쓰지 않음", func(t *testing.T) {
+		assert.NoError(t, snapshots.Save(ctx, domain.Snapshot{AccountID: "account-1", Version: 5, State: []byte(`{}`), CreatedAt: time.Now()}))
+		assert.NoError(t, snapshots.Save(ctx, domain.Snapshot{AccountID: "account-1", Version: 3, State: []byte(`{}`), CreatedAt: time.Now()}))
+
+		snapshot, err := snapshots.Load(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), snapshot.Version)
+
+		snapshot, err = snapshots.Load(ctx, "account-2")
+		assert.NoError(t, err)
+		assert.Nil(t, snapshot)
+	})
+
+	t.Run("트랜잭션 안의 RunInTransaction 은 같은 커넥션에서 세이브포인트로 중첩", func(t *testing.T) {
+		inbox := NewInboxStore(db)
+		tctx, err := db.Begin(ctx)
+		assert.NoError(t, err)
+
+		cause := errors.New("failed")
+		err = db.RunInTransaction(tctx, func(nctx context.Context) error {
+			_, err := inbox.Record(nctx, "failing", "event-1")
+			assert.NoError(t, err)
+			return cause
+		})
+		assert.ErrorIs(t, err, cause)
+		assert.NoError(t, db.RunInTransaction(tctx, func(nctx context.Context) error {
+			_, err := inbox.Record(nctx, "handler", "event-1")
+			return err
+		}))
+		assert.NoError(t, db.Commit(tctx))
+
+		// 실패한 중첩 트랜잭션의 기록만 되돌려짐
+		first, err := inbox.Record(ctx, "failing", "event-1")
+		assert.NoError(t, err)
+		assert.True(t, first)
+		first, err = inbox.Record(ctx, "handler", "event-1")
+		assert.NoError(t, err)
+		assert.False(t, first)
+	})
+
+	t.Run("다시 열어도 데이터 유지", func(t *testing.T) {
+		assert.NoError(t, db.Close())
+
+		reopened, err := NewSQLiteDB(path)
+		assert.NoError(t, err)
+		defer reopened.Close()
+
+		stored, err := NewEventStore(reopened).Load(ctx, "account-1")
+		assert.NoError(t, err)
+		assert.Len(t, stored, 2)
+	})
+}
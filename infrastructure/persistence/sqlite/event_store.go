@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/gormstore"
+	"gorm.io/gorm"
+)
+
+type EventStore struct {
+	db *SQLiteDB
+}
+
+func NewEventStore(db *SQLiteDB) *EventStore {
+	return &EventStore{
+		db: db,
+	}
+}
+
+// Save 이벤트들을 expectedVersion 다음 버전부터 순서대로 저장하고 발급된 Version, Position 을 events 에 채움
+// 동시에 같은 버전을 쓰려는 요청은 (account_id, version) 유니크 제약에 걸려 ErrConcurrencyConflict 로 변환됨
+// 컨텍스트에 트랜잭션이 있으면 세이브포인트로, 없으면 새 트랜잭션으로 실행
+func (r *EventStore) Save(ctx context.Context, accountId string, expectedVersion int64, events []domain.Event) error {
+	if err := domain.ValidateNewEvents(events); err != nil {
+		return fmt.Errorf("failed to save events of %s: %w", accountId, err)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.db.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.save(tx, accountId, expectedVersion, events)
+	})
+}
+
+// save 연결이 하나라서 쓰기가 직렬화되므로 position 은 현재 최댓값 다음부터 직접 발급
+func (r *EventStore) save(tx *gorm.DB, accountId string, expectedVersion int64, events []domain.Event) error {
+	var currentVersion int64
+	if err := tx.Model(&domain.Event{}).
+		Where("account_id = ?", accountId).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&currentVersion).Error; err != nil {
+		return fmt.Errorf("failed to read stream version: %v", err)
+	}
+	if currentVersion != expectedVersion {
+		return fmt.Errorf("%w: expected version %d, current version %d",
+			domain.ErrConcurrencyConflict, expectedVersion, currentVersion)
+	}
+
+	var lastPosition int64
+	if err := tx.Model(&domain.Event{}).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&lastPosition).Error; err != nil {
+		return fmt.Errorf("failed to read event log position: %v", err)
+	}
+
+	for i := range events {
+		events[i].Version = expectedVersion + int64(i) + 1
+		events[i].Position = lastPosition + int64(i) + 1
+		// 시각은 문자열로 저장되므로 UTC 로 맞춰야 created_at 범위 비교가 시각 순서와 같아짐
+		events[i].CreatedAt = events[i].CreatedAt.UTC()
+	}
+
+	// 이미 저장된 ID 가 있으면 어느 이벤트인지 알려주기 위해 INSERT 전에 확인
+	if err := gormstore.CheckExistingIDs(tx, events); err != nil {
+		return err
+	}
+
+	// SaveBatchSize 개씩 다중 행 INSERT
+	for from := 0; from < len(events); from += gormstore.SaveBatchSize {
+		to := min(from+gormstore.SaveBatchSize, len(events))
+		batch := events[from:to]
+		if err := tx.Create(&batch).Error; err != nil {
+			return gormstore.InsertError(tx, "", accountId, events, from, to, err)
+		}
+	}
+	return nil
+}
+
+// Load 특정 계좌의 모든 이벤트를 버전 순서대로 조회 (애그리거트 재구성용)
+func (r *EventStore) Load(ctx context.Context, accountId string) ([]domain.Event, error) {
+	var events []domain.Event
+	tx := r.db.conn(ctx).
+		Where("account_id = ?", accountId).
+		Order("version asc").
+		Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	// 이전 스키마 버전으로 저장된 페이로드는 현재 버전으로 변환해서 반환
+	return domain.UpcastEvents(events)
+}
+
+// LoadAfter afterVersion 이후의 이벤트만 버전 순서대로 조회
+func (r *EventStore) LoadAfter(ctx context.Context, accountId string, afterVersion int64) ([]domain.Event, error) {
+	var events []domain.Event
+	tx := r.db.conn(ctx).
+		Where("account_id = ? AND version > ?", accountId, afterVersion).
+		Order("version asc").
+		Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return domain.UpcastEvents(events)
+}
+
+// ReadAll fromPosition 이후의 이벤트를 계좌와 상관없이 position 순서대로 조회
+func (r *EventStore) ReadAll(ctx context.Context, fromPosition int64, limit int) ([]domain.Event, error) {
+	var events []domain.Event
+	tx := r.db.conn(ctx).
+		Where("position > ?", fromPosition).
+		Order("position asc").
+		Limit(limit).
+		Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return domain.UpcastEvents(events)
+}
+
+// LoadRange 계좌 스트림에서 조건에 맞는 이벤트를 버전 순서대로 조회
+func (r *EventStore) LoadRange(ctx context.Context, accountId string, query domain.EventQuery) ([]domain.Event, error) {
+	tx := r.db.conn(ctx).Where("account_id = ?", accountId)
+	if query.FromVersion > 0 {
+		tx = tx.Where("version >= ?", query.FromVersion)
+	}
+	if query.ToVersion > 0 {
+		tx = tx.Where("version <= ?", query.ToVersion)
+	}
+	// created_at 은 UTC 문자열로 저장되므로 비교할 시각도 UTC 로 변환
+	if !query.FromTime.IsZero() {
+		tx = tx.Where("created_at >= ?", query.FromTime.UTC())
+	}
+	if !query.ToTime.IsZero() {
+		tx = tx.Where("created_at < ?", query.ToTime.UTC())
+	}
+	if len(query.EventTypes) > 0 {
+		tx = tx.Where("event_type IN ?", query.EventTypeNames())
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	var events []domain.Event
+	if err := tx.Order("version asc").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return domain.UpcastEvents(events)
+}
+
+// Stream 조건에 맞는 이벤트를 domain.StreamPageSize 개씩 나눠 읽는 반복자
+func (r *EventStore) Stream(ctx context.Context, accountId string, query domain.EventQuery) domain.EventIterator {
+	return domain.NewPagedEventIterator(ctx, r.LoadRange, accountId, query)
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"go-eventsourcing-patterns/domain"
+	"go-eventsourcing-patterns/infrastructure/persistence/eventstoretest"
+	"go-eventsourcing-patterns/infrastructure/persistence/gormstore"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestEventStoreConformance(t *testing.T) {
+	eventstoretest.Run(t, func(t *testing.T) eventstoretest.Fixture {
+		db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "eventstore.db"))
+		if err != nil {
+			t.Fatalf("failed to open sqlite: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return eventstoretest.Fixture{Store: NewEventStore(db), TxManager: db}
+	})
+}
+
+// TestInsertError INSERT 가 유니크 제약에 걸리면 배치 안에서 원인이 된 이벤트를 알려줌
+func TestInsertError(t *testing.T) {
+	db, err := NewSQLiteDB(filepath.Join(t.TempDir(), "eventstore.db"))
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	defer db.Close()
+
+	eventstoretest.RunInsertErrors(t, NewEventStore(db), func(accountID string, events []domain.Event, from int, firstVersion int64) error {
+		return db.GetDB().Transaction(func(tx *gorm.DB) error {
+			batch := events[from:]
+			for i := range batch {
+				batch[i].Version = firstVersion + int64(i)
+				batch[i].Position = 100 + batch[i].Version
+			}
+			if err := tx.Create(&batch).Error; err != nil {
+				return gormstore.InsertError(tx, "", accountID, events, from, len(events), err)
+			}
+			return nil
+		})
+	})
+}
+
+// BenchmarkEventStoreSave 다중 행 INSERT 로 저장하는 Save 와 이벤트마다 INSERT 하는 방식 비교
+//
+//	go test -run '^$' -bench EventStoreSave ./infrastructure/persistence/sqlite/
+func BenchmarkEventStoreSave(b *testing.B) {
+	db, err := NewSQLiteDB(filepath.Join(b.TempDir(), "eventstore.db"))
+	if err != nil {
+		b.Fatalf("failed to open sqlite: %v", err)
+	}
+	defer db.Close()
+
+	eventstoretest.BenchmarkSave(b, NewEventStore(db), func(accountID string, events []domain.Event) error {
+		return db.GetDB().Transaction(func(tx *gorm.DB) error {
+			var lastPosition int64
+			if err := tx.Model(&domain.Event{}).Select("COALESCE(MAX(position), 0)").Scan(&lastPosition).Error; err != nil {
+				return err
+			}
+			for i := range events {
+				events[i].Version = int64(i) + 1
+				events[i].Position = lastPosition + int64(i) + 1
+				if err := tx.Create(&events[i]).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
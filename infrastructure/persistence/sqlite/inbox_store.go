@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type InboxStore struct {
+	db *SQLiteDB
+}
+
+func NewInboxStore(db *SQLiteDB) *InboxStore {
+	return &InboxStore{
+		db: db,
+	}
+}
+
+// Record (handler_name, event_id) 가 처음 기록될 때만 true 반환
+func (r *InboxStore) Record(ctx context.Context, handlerName string, eventID string) (bool, error) {
+	tx := r.db.conn(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.ProcessedEvent{
+			HandlerName: handlerName,
+			EventID:     eventID,
+			ProcessedAt: time.Now(),
+		})
+	if tx.Error != nil {
+		return false, fmt.Errorf("failed to record processed event: %v", tx.Error)
+	}
+	return tx.RowsAffected == 1, nil
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-eventsourcing-patterns/domain"
+	"time"
+)
+
+type OutboxStore struct {
+	db *SQLiteDB
+}
+
+func NewOutboxStore(db *SQLiteDB) *OutboxStore {
+	return &OutboxStore{
+		db: db,
+	}
+}
+
+// Add 이벤트들을 outbox 테이블에 기록 (커맨드와 같은 트랜잭션)
+func (r *OutboxStore) Add(ctx context.Context, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]domain.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		if event.ID == "" {
+			return fmt.Errorf("%w: %s event of %s", domain.ErrMissingEventID, event.EventType, event.AccountID)
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %v", err)
+		}
+		messages = append(messages, domain.OutboxMessage{
+			EventID:   event.ID,
+			AccountID: event.AccountID,
+			Payload:   payload,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	if err := r.db.conn(ctx).Create(&messages).Error; err != nil {
+		return fmt.Errorf("failed to add outbox messages: %v", err)
+	}
+	return nil
+}
+
+// FetchPending 미발행 메시지를 id 순서대로 조회
+// SQLite 는 행 잠금이 없지만 연결이 하나라서 트랜잭션이 끝날 때까지 다른 릴레이가 같은 메시지를 가져가지 않음
+func (r *OutboxStore) FetchPending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	var messages []domain.OutboxMessage
+	tx := r.db.conn(ctx).
+		Where("sent_at IS NULL").
+		Order("id asc").
+		Limit(limit).
+		Find(&messages)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return messages, nil
+}
+
+// MarkSent 발행 완료 시각 기록
+func (r *OutboxStore) MarkSent(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tx := r.db.conn(ctx).
+		Model(&domain.OutboxMessage{}).
+		Where("id IN ?", ids).
+		Update("sent_at", time.Now())
+	return tx.Error
+}
@@ -0,0 +1,73 @@
+-- infrastructure/persistence/sqlite/schema.sql
+-- deployments/postgres/init.sql 과 같은 스키마 (NewSQLiteDB 에서 생성)
+-- accounts 는 events 로부터 파생되는 프로젝션
+CREATE TABLE IF NOT EXISTS accounts (
+    id         TEXT PRIMARY KEY,
+    user_name  TEXT NOT NULL,
+    balance    INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS events (
+    id             TEXT PRIMARY KEY,
+    account_id     TEXT NOT NULL,
+    event_type     TEXT NOT NULL,
+    event_data     BLOB NOT NULL,
+    schema_version INTEGER NOT NULL DEFAULT 1,
+    version        INTEGER NOT NULL,
+    -- 전체 이벤트 로그 내 순번 (ReadAll 로 순서대로 따라 읽기 위함)
+    position       INTEGER NOT NULL,
+    created_at     DATETIME DEFAULT CURRENT_TIMESTAMP,
+    -- 스트림 내 버전 중복을 막아 낙관적 동시성 제어
+    CONSTRAINT uq_events_account_version UNIQUE (account_id, version),
+    CONSTRAINT uq_events_position UNIQUE (position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_account_id ON events(account_id);
+
+-- 계좌별 최신 애그리거트 스냅샷
+CREATE TABLE IF NOT EXISTS snapshots (
+    account_id TEXT PRIMARY KEY,
+    version    INTEGER NOT NULL,
+    state      BLOB NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 트랜잭셔널 아웃박스
+CREATE TABLE IF NOT EXISTS outbox (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id   TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    payload    BLOB NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    sent_at    DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(id) WHERE sent_at IS NULL;
+
+-- 컨슈머 인박스
+CREATE TABLE IF NOT EXISTS inbox (
+    handler_name TEXT NOT NULL,
+    event_id     TEXT NOT NULL,
+    processed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (handler_name, event_id)
+);
+
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    projection_name TEXT PRIMARY KEY,
+    position        INTEGER NOT NULL,
+    updated_at      DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS account_summaries (
+    account_id        TEXT PRIMARY KEY,
+    user_name         TEXT NOT NULL DEFAULT '',
+    balance           INTEGER NOT NULL DEFAULT 0,
+    total_deposits    INTEGER NOT NULL DEFAULT 0,
+    total_withdrawals INTEGER NOT NULL DEFAULT 0,
+    transaction_count INTEGER NOT NULL DEFAULT 0,
+    created_at        DATETIME NOT NULL,
+    last_activity_at  DATETIME NOT NULL,
+    version           INTEGER NOT NULL DEFAULT 0
+);
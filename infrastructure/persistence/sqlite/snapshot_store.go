@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"go-eventsourcing-patterns/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SnapshotStore struct {
+	db *SQLiteDB
+}
+
+func NewSnapshotStore(db *SQLiteDB) *SnapshotStore {
+	return &SnapshotStore{
+		db: db,
+	}
+}
+
+// Save 계좌별 스냅샷 upsert (더 오래된 버전으로 덮어쓰지 않음)
+func (r *SnapshotStore) Save(ctx context.Context, snapshot domain.Snapshot) error {
+	tx := r.db.conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version", "state", "created_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "snapshots.version < EXCLUDED.version"},
+		}},
+	}).Create(&snapshot)
+	return tx.Error
+}
+
+// Load 계좌의 최신 스냅샷 조회
+func (r *SnapshotStore) Load(ctx context.Context, accountID string) (*domain.Snapshot, error) {
+	var snapshot domain.Snapshot
+	tx := r.db.conn(ctx).First(&snapshot, "account_id = ?", accountID)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return &snapshot, nil
+}
@@ -1,13 +1,25 @@
 package http
 
 import (
+	"errors"
 	"github.com/google/uuid"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go-eventsourcing-patterns/domain"
 )
 
+// 읽기 일관성 헤더
+// 커맨드 응답에 이벤트 position 을 담아 보내고, 클라이언트가 조회 요청에 그 값을 X-Min-Position 으로 넘기면
+// 조회 모델이 그 커맨드까지 반영한 뒤에 응답함 (read-your-writes)
+const (
+	HeaderEventPosition = "X-Event-Position"
+	HeaderStreamVersion = "X-Stream-Version"
+	HeaderMinPosition   = "X-Min-Position"
+)
+
 type AccountHandler struct {
 	commandService domain.AccountCommandService
 	queryService   domain.AccountQueryService
@@ -37,14 +49,17 @@ func (h *AccountHandler) CreateAccount(c *gin.Context) {
 		UserName:       req.UserName,
 		AccountId:      accountId,
 	}
-	if err := h.commandService.CreateAccount(c, cmd); err != nil {
+	result, err := h.commandService.CreateAccount(c, cmd)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	setResultHeaders(c, result)
 
-	account, err := h.queryService.GetAccountByID(c, accountId)
+	// 방금 저장한 이벤트까지 반영된 조회 모델에서 읽음
+	account, err := h.queryService.GetAccountByID(c, accountId, result.Position)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(queryErrorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -63,14 +78,17 @@ func (h *AccountHandler) Deposit(c *gin.Context) {
 		Amount:    req.Amount,
 	}
 
-	if err := h.commandService.Deposit(c, cmd); err != nil {
+	result, err := h.commandService.Deposit(c, cmd)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	setResultHeaders(c, result)
 
-	account, err := h.queryService.GetAccountByID(c, req.AccountID)
+	// 방금 저장한 이벤트까지 반영된 조회 모델에서 읽음
+	account, err := h.queryService.GetAccountByID(c, req.AccountID, result.Position)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(queryErrorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -89,14 +107,17 @@ func (h *AccountHandler) Withdraw(c *gin.Context) {
 		Amount:    req.Amount,
 	}
 
-	if err := h.commandService.Withdraw(c, cmd); err != nil {
+	result, err := h.commandService.Withdraw(c, cmd)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	setResultHeaders(c, result)
 
-	account, err := h.queryService.GetAccountByID(c, req.AccountId)
+	// 방금 저장한 이벤트까지 반영된 조회 모델에서 읽음
+	account, err := h.queryService.GetAccountByID(c, req.AccountId, result.Position)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(queryErrorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -110,19 +131,56 @@ func (h *AccountHandler) GetAccount(c *gin.Context) {
 		return
 	}
 
-	account, err := h.queryService.GetAccountByID(c, req.AccountId)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	// as_of 나 version 이 있으면 현재 조회 모델 대신 이벤트를 그 시점까지 재생한 상태를 반환
+	var account *domain.AccountResponse
+	switch {
+	case req.AsOf != "" && req.Version != 0:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "as_of and version cannot be used together"})
 		return
+	case req.AsOf != "":
+		asOf, err := parseAsOf(req.AsOf)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		account, err = h.queryService.GetAccountAsOf(c, req.AccountId, asOf)
+		if err != nil {
+			c.JSON(replayErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+	case req.Version != 0:
+		var err error
+		account, err = h.queryService.GetAccountAtVersion(c, req.AccountId, req.Version)
+		if err != nil {
+			c.JSON(replayErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+	default:
+		minPosition, err := minPositionOf(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		account, err = h.queryService.GetAccountByID(c, req.AccountId, minPosition)
+		if err != nil {
+			c.JSON(queryErrorStatus(err, http.StatusNotFound), gin.H{"error": err.Error()})
+			return
+		}
 	}
 
 	c.JSON(http.StatusOK, account)
 }
 
 func (h *AccountHandler) ListAccounts(c *gin.Context) {
-	accounts, err := h.queryService.ListAccounts(c)
+	minPosition, err := minPositionOf(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accounts, err := h.queryService.ListAccounts(c, minPosition)
+	if err != nil {
+		c.JSON(queryErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -137,6 +195,53 @@ func (h *AccountHandler) ListAccounts(c *gin.Context) {
 	c.JSON(http.StatusOK, res)
 }
 
+// setResultHeaders 커맨드가 저장한 이벤트의 스트림 버전과 전체 로그 position 을 응답 헤더로 전달
+func setResultHeaders(c *gin.Context, result domain.CommandResult) {
+	c.Header(HeaderStreamVersion, strconv.FormatInt(result.Version, 10))
+	c.Header(HeaderEventPosition, strconv.FormatInt(result.Position, 10))
+}
+
+// minPositionOf X-Min-Position 헤더 값, 없으면 0 (기다리지 않음)
+func minPositionOf(c *gin.Context) (int64, error) {
+	v := c.GetHeader(HeaderMinPosition)
+	if v == "" {
+		return 0, nil
+	}
+	position, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || position < 0 {
+		return 0, errors.New("invalid " + HeaderMinPosition + " header")
+	}
+	return position, nil
+}
+
+// parseAsOf RFC3339 시각은 그대로, 날짜만 주면 그날이 끝나는 시각(다음 날 0시 UTC)으로 해석
+// 예) as_of=2026-03-31 은 3월 31일의 마지막 거래까지 반영한 상태
+func parseAsOf(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+		return t, nil
+	}
+	if d, err := time.Parse(time.DateOnly, v); err == nil {
+		return d.AddDate(0, 0, 1), nil
+	}
+	return time.Time{}, errors.New("invalid as_of, use RFC3339 or YYYY-MM-DD")
+}
+
+// queryErrorStatus 조회 모델이 아직 따라잡지 못했으면 503, 그 외에는 fallback
+func queryErrorStatus(err error, fallback int) int {
+	if errors.Is(err, domain.ErrReadModelBehind) {
+		return http.StatusServiceUnavailable
+	}
+	return fallback
+}
+
+// replayErrorStatus 시점 조회 에러의 상태 코드, 계좌나 그 버전이 없으면 404 그 밖은 500
+func replayErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrAccountNotFound) || errors.Is(err, domain.ErrVersionNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
 func (h *AccountHandler) GetHealthCheck(c *gin.Context) {
 	if h == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"status": "un healthy"})
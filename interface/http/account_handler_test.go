@@ -3,6 +3,8 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +13,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestAccountService(t *testing.T) {
@@ -28,10 +31,10 @@ func TestAccountService(t *testing.T) {
 
 		mockCommandService.EXPECT().
 			CreateAccount(gomock.Any(), gomock.Any()).
-			Return(nil)
+			Return(domain.CommandResult{AccountID: "test-id", Version: 1, Position: 1}, nil)
 
 		mockQueryService.EXPECT().
-			GetAccountByID(gomock.Any(), gomock.Any()).
+			GetAccountByID(gomock.Any(), gomock.Any(), int64(1)).
 			Return(&domain.AccountResponse{
 				ID:       "test-id",
 				Balance:  1000,
@@ -50,6 +53,8 @@ func TestAccountService(t *testing.T) {
 
 		router.ServeHTTP(resp, req)
 		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "1", resp.Header().Get(HeaderEventPosition))
+		assert.Equal(t, "1", resp.Header().Get(HeaderStreamVersion))
 
 		var response domain.AccountResponse
 
@@ -70,10 +75,10 @@ func TestAccountService(t *testing.T) {
 
 		mockCommandService.EXPECT().
 			Deposit(gomock.Any(), gomock.Any()).
-			Return(nil)
+			Return(domain.CommandResult{AccountID: "test-id", Version: 2, Position: 7}, nil)
 
 		mockQueryService.EXPECT().
-			GetAccountByID(gomock.Any(), gomock.Any()).
+			GetAccountByID(gomock.Any(), gomock.Any(), int64(7)).
 			Return(&domain.AccountResponse{
 				ID:       "test-id",
 				Balance:  2000,
@@ -111,10 +116,10 @@ func TestAccountService(t *testing.T) {
 
 		mockCommandService.EXPECT().
 			Withdraw(gomock.Any(), gomock.Any()).
-			Return(nil)
+			Return(domain.CommandResult{AccountID: "test-id", Version: 3, Position: 8}, nil)
 
 		mockQueryService.EXPECT().
-			GetAccountByID(gomock.Any(), gomock.Any()).
+			GetAccountByID(gomock.Any(), gomock.Any(), int64(8)).
 			Return(&domain.AccountResponse{
 				ID:       "test-id",
 				Balance:  1000,
@@ -141,4 +146,92 @@ func TestAccountService(t *testing.T) {
 		assert.NotEmpty(t, response.UserName)
 		assert.Equal(t, int64(1000), response.Balance)
 	})
+
+	t.Run("GetAccount 는 X-Min-Position 까지 기다림", func(t *testing.T) {
+		mockCommandService := mock.NewMockAccountCommandService(ctrl)
+		mockQueryService := mock.NewMockAccountQueryService(ctrl)
+
+		handler := NewAccountHandler(mockCommandService, mockQueryService)
+		router := gin.New()
+		handler.SetupRoutes(router)
+
+		mockQueryService.EXPECT().
+			GetAccountByID(gomock.Any(), "account_id", int64(42)).
+			Return(nil, domain.ErrReadModelBehind)
+
+		req := httptest.NewRequest("GET", "/v1/account.info?account_id=account_id", nil)
+		req.Header.Set(HeaderMinPosition, "42")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+		req = httptest.NewRequest("GET", "/v1/account.info?account_id=account_id", nil)
+		req.Header.Set(HeaderMinPosition, "not-a-number")
+		resp = httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("GetAccount 는 as_of 시점의 상태를 조회", func(t *testing.T) {
+		mockCommandService := mock.NewMockAccountCommandService(ctrl)
+		mockQueryService := mock.NewMockAccountQueryService(ctrl)
+
+		handler := NewAccountHandler(mockCommandService, mockQueryService)
+		router := gin.New()
+		handler.SetupRoutes(router)
+
+		// 날짜만 주면 그날이 끝나는 시각까지
+		mockQueryService.EXPECT().
+			GetAccountAsOf(gomock.Any(), "account_id", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)).
+			Return(&domain.AccountResponse{ID: "account_id", Balance: 120, Version: 3}, nil)
+
+		req := httptest.NewRequest("GET", "/v1/account.info?account_id=account_id&as_of=2026-03-31", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var response domain.AccountResponse
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &response))
+		assert.Equal(t, int64(120), response.Balance)
+
+		req = httptest.NewRequest("GET", "/v1/account.info?account_id=account_id&as_of=yesterday", nil)
+		resp = httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("GetAccount 시점 조회는 계좌나 버전이 없을 때만 404", func(t *testing.T) {
+		mockCommandService := mock.NewMockAccountCommandService(ctrl)
+		mockQueryService := mock.NewMockAccountQueryService(ctrl)
+
+		handler := NewAccountHandler(mockCommandService, mockQueryService)
+		router := gin.New()
+		handler.SetupRoutes(router)
+
+		gomock.InOrder(
+			mockQueryService.EXPECT().GetAccountAtVersion(gomock.Any(), "account_id", int64(9)).
+				Return(nil, fmt.Errorf("%w: account_id is at version 3", domain.ErrVersionNotFound)),
+			mockQueryService.EXPECT().GetAccountAtVersion(gomock.Any(), "account_id", int64(9)).
+				Return(nil, errors.New("connection refused")),
+			mockQueryService.EXPECT().GetAccountAsOf(gomock.Any(), "account_id", gomock.Any()).
+				Return(nil, fmt.Errorf("%w: account_id", domain.ErrAccountNotFound)),
+			mockQueryService.EXPECT().GetAccountAsOf(gomock.Any(), "account_id", gomock.Any()).
+				Return(nil, errors.New("connection refused")),
+		)
+
+		for _, tc := range []struct {
+			query string
+			code  int
+		}{
+			{"version=9", http.StatusNotFound},
+			{"version=9", http.StatusInternalServerError},
+			{"as_of=2026-03-31", http.StatusNotFound},
+			{"as_of=2026-03-31", http.StatusInternalServerError},
+		} {
+			req := httptest.NewRequest("GET", "/v1/account.info?account_id=account_id&"+tc.query, nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+			assert.Equal(t, tc.code, resp.Code, tc.query)
+		}
+	})
 }